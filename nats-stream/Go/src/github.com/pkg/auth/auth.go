@@ -0,0 +1,191 @@
+// Package auth verifies JWT bearer tokens on incoming HTTP requests and
+// authorizes the NATS subjects a token's holder may publish to, mirroring
+// the subject-permission model NATS itself uses for client accounts.
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/broker"
+)
+
+// Claims is the payload of a bookserver access token. Publish lists the
+// NATS subject patterns (supporting the usual "*"/">" wildcards) the
+// token's holder may publish to; a handler that turns an HTTP request into
+// a domain event checks the request's subject against this list before
+// publishing.
+type Claims struct {
+	jwt.RegisteredClaims
+	Publish []string `json:"pub"`
+}
+
+// contextKey is unexported so other packages can't collide with it.
+type contextKey int
+
+const claimsKey contextKey = 0
+
+// FromContext returns the Claims stored in ctx by Middleware, if any.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey).(*Claims)
+	return claims, ok
+}
+
+// ErrMissingToken is returned by Middleware's rejected requests via a 401
+// response; handlers never see it directly.
+var ErrMissingToken = errors.New("auth: missing bearer token")
+
+// Config is the key material and validation rules Middleware checks a
+// token against. At least one of HMACSecret/RSAPublicKey must be set;
+// tokens signed with an algorithm that has no corresponding key configured
+// are rejected, which also rules out algorithm-confusion attacks that
+// present an HS256 token signed with a key derived from the RS256 public
+// key (or vice versa).
+type Config struct {
+	// HMACSecret verifies HS256-signed tokens. Leave nil to refuse HS256.
+	HMACSecret []byte
+	// RSAPublicKey verifies RS256-signed tokens. Leave nil to refuse RS256.
+	RSAPublicKey *rsa.PublicKey
+	// Issuer, if set, must match the token's iss claim.
+	Issuer string
+	// Audience, if set, must appear in the token's aud claim.
+	Audience string
+}
+
+func (cfg Config) validMethods() []string {
+	var methods []string
+	if cfg.HMACSecret != nil {
+		methods = append(methods, "HS256")
+	}
+	if cfg.RSAPublicKey != nil {
+		methods = append(methods, "RS256")
+	}
+	return methods
+}
+
+// keyFunc picks the verification key based on the token's own declared
+// algorithm. jwt.WithValidMethods (passed alongside in parserOptions) is
+// what actually prevents a caller from choosing the algorithm; this just
+// maps the algorithm jwt has already restricted to its key.
+func (cfg Config) keyFunc(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case "HS256":
+		return cfg.HMACSecret, nil
+	case "RS256":
+		return cfg.RSAPublicKey, nil
+	default:
+		return nil, errors.New("auth: unsupported signing method " + token.Method.Alg())
+	}
+}
+
+func (cfg Config) parserOptions() []jwt.ParserOption {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(cfg.validMethods()),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	return opts
+}
+
+// Middleware returns HTTP middleware that parses and verifies the bearer
+// token on every request against cfg, rejecting the request with 401 if it
+// is missing or invalid and otherwise storing its Claims in the request
+// context for downstream handlers.
+func Middleware(cfg Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, err := bearerToken(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			claims := &Claims{}
+			_, err = jwt.ParseWithClaims(token, claims, cfg.keyFunc, cfg.parserOptions()...)
+			if err != nil {
+				http.Error(w, "auth: invalid token: "+err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), claimsKey, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", ErrMissingToken
+	}
+	return strings.TrimPrefix(header, prefix), nil
+}
+
+// Authorize reports whether claims permits publishing to subject, i.e.
+// whether any of its Publish patterns matches subject under NATS subject
+// wildcard rules ("*" matches exactly one token, ">" matches one or more
+// trailing tokens).
+func Authorize(claims *Claims, subject string) bool {
+	for _, pattern := range claims.Publish {
+		if subjectMatches(pattern, subject) {
+			return true
+		}
+	}
+	return false
+}
+
+func subjectMatches(pattern, subject string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	subjectTokens := strings.Split(subject, ".")
+
+	for i, pt := range patternTokens {
+		if pt == ">" {
+			return i < len(subjectTokens)
+		}
+		if i >= len(subjectTokens) {
+			return false
+		}
+		if pt != "*" && pt != subjectTokens[i] {
+			return false
+		}
+	}
+	return len(patternTokens) == len(subjectTokens)
+}
+
+// ErrUnauthorized is returned by AuthorizedPublisher.AuthorizedPublish when
+// its claims don't permit publishing to the requested subject.
+var ErrUnauthorized = errors.New("auth: not authorized to publish to this subject")
+
+// AuthorizedPublisher publishes to a broker subject only if the claims it
+// was built with authorize that subject.
+type AuthorizedPublisher interface {
+	AuthorizedPublish(ctx context.Context, subject string, data []byte) error
+}
+
+// NewPublisher adapts pub into an AuthorizedPublisher that rejects any
+// subject claims doesn't authorize before delegating to pub.Publish.
+func NewPublisher(pub broker.Publisher, claims *Claims) AuthorizedPublisher {
+	return authorizedPublisher{Publisher: pub, claims: claims}
+}
+
+type authorizedPublisher struct {
+	broker.Publisher
+	claims *Claims
+}
+
+func (p authorizedPublisher) AuthorizedPublish(ctx context.Context, subject string, data []byte) error {
+	if !Authorize(p.claims, subject) {
+		return ErrUnauthorized
+	}
+	return p.Publish(ctx, subject, broker.Message{Subject: subject, Data: data})
+}
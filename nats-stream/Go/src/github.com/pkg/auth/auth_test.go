@@ -0,0 +1,44 @@
+package auth
+
+import "testing"
+
+func TestSubjectMatches(t *testing.T) {
+	cases := []struct {
+		pattern, subject string
+		want             bool
+	}{
+		{"books.>", "books.created", true},
+		{"books.>", "books.created.v2", true},
+		{"books.>", "books", false},
+		{"books.*", "books.created", true},
+		{"books.*", "books.created.v2", false},
+		{"books.created", "books.created", true},
+		{"books.created", "books.deleted", false},
+		{"books.created", "books.created.extra", false},
+	}
+	for _, c := range cases {
+		if got := subjectMatches(c.pattern, c.subject); got != c.want {
+			t.Errorf("subjectMatches(%q, %q) = %v, want %v", c.pattern, c.subject, got, c.want)
+		}
+	}
+}
+
+func TestAuthorizeChecksEveryPattern(t *testing.T) {
+	claims := &Claims{Publish: []string{"books.created", "orders.>"}}
+
+	if !Authorize(claims, "books.created") {
+		t.Error("Authorize(books.created) = false, want true")
+	}
+	if !Authorize(claims, "orders.placed") {
+		t.Error("Authorize(orders.placed) = false, want true")
+	}
+	if Authorize(claims, "books.deleted") {
+		t.Error("Authorize(books.deleted) = true, want false")
+	}
+}
+
+func TestAuthorizeEmptyPublishList(t *testing.T) {
+	if Authorize(&Claims{}, "books.created") {
+		t.Error("Authorize with no Publish patterns = true, want false")
+	}
+}
@@ -0,0 +1,44 @@
+// Package broker defines a messaging abstraction the rest of this project
+// codes against, so a subject/stream can be published to or subscribed from
+// without the caller depending on jsclient (or any other broker) directly.
+// github.com/pkg/broker/natsjs provides the JetStream-backed implementation
+// this project actually runs on; a different backend only needs to satisfy
+// Publisher and Subscriber.
+package broker
+
+import "context"
+
+// Message is a single unit of data moving through a broker, independent of
+// how the underlying transport represents it.
+type Message struct {
+	Subject string
+	Data    []byte
+	Headers map[string]string
+}
+
+// Handler processes a single Message delivered to a subscription.
+type Handler func(ctx context.Context, msg Message)
+
+// Subscription is returned by Subscriber.Subscribe; callers use it to stop
+// receiving messages.
+type Subscription interface {
+	Unsubscribe() error
+	Drain() error
+}
+
+// Publisher sends messages to a subject.
+type Publisher interface {
+	Publish(ctx context.Context, subject string, msg Message) error
+}
+
+// Subscriber receives messages published to a subject.
+type Subscriber interface {
+	Subscribe(ctx context.Context, subject string, handler Handler) (Subscription, error)
+}
+
+// PubSub is the union most callers actually want: something that can both
+// publish and subscribe.
+type PubSub interface {
+	Publisher
+	Subscriber
+}
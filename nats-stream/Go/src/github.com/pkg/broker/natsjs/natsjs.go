@@ -0,0 +1,64 @@
+// Package natsjs implements github.com/pkg/broker on top of jsclient, so
+// the broker-agnostic Publisher/Subscriber interfaces have a concrete,
+// JetStream-backed implementation to run against.
+package natsjs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/broker"
+	"github.com/pkg/jsclient"
+)
+
+// Broker adapts a single jsclient.Client stream to broker.PubSub.
+type Broker struct {
+	client *jsclient.Client
+	stream string
+	opts   jsclient.Options
+}
+
+// New wraps client, publishing to and subscribing from stream.
+func New(client *jsclient.Client, stream string, opts jsclient.Options) *Broker {
+	return &Broker{client: client, stream: stream, opts: opts}
+}
+
+// Publish implements broker.Publisher.
+func (b *Broker) Publish(ctx context.Context, subject string, msg broker.Message) error {
+	m := nats.NewMsg(jsclient.Channel(b.stream, subject))
+	for k, v := range msg.Headers {
+		m.Header.Set(k, v)
+	}
+	m.Data = msg.Data
+	if err := b.client.PublishMsg(b.stream, m, b.opts); err != nil {
+		return fmt.Errorf("natsjs: publish %s: %w", subject, err)
+	}
+	return nil
+}
+
+// Subscribe implements broker.Subscriber.
+func (b *Broker) Subscribe(ctx context.Context, subject string, handler broker.Handler) (broker.Subscription, error) {
+	sub, err := b.client.Subscribe(b.stream, subject, func(m *nats.Msg) {
+		handler(ctx, toMessage(m))
+	}, b.opts)
+	if err != nil {
+		return nil, fmt.Errorf("natsjs: subscribe %s: %w", subject, err)
+	}
+	return subscription{sub}, nil
+}
+
+func toMessage(m *nats.Msg) broker.Message {
+	headers := make(map[string]string, len(m.Header))
+	for k := range m.Header {
+		headers[k] = m.Header.Get(k)
+	}
+	return broker.Message{Subject: m.Subject, Data: m.Data, Headers: headers}
+}
+
+type subscription struct {
+	sub *jsclient.Subscription
+}
+
+func (s subscription) Unsubscribe() error { return s.sub.Unsubscribe() }
+func (s subscription) Drain() error       { return s.sub.Drain() }
@@ -0,0 +1,32 @@
+package natsjs
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestToMessageCopiesSubjectDataAndHeaders(t *testing.T) {
+	m := nats.NewMsg("books.created")
+	m.Header.Set("trace-id", "abc")
+	m.Data = []byte("payload")
+
+	got := toMessage(m)
+	if got.Subject != "books.created" {
+		t.Errorf("Subject = %q, want books.created", got.Subject)
+	}
+	if string(got.Data) != "payload" {
+		t.Errorf("Data = %q, want payload", got.Data)
+	}
+	if got.Headers["trace-id"] != "abc" {
+		t.Errorf("Headers[trace-id] = %q, want abc", got.Headers["trace-id"])
+	}
+}
+
+func TestToMessageNilHeader(t *testing.T) {
+	m := nats.NewMsg("books.created")
+	got := toMessage(m)
+	if len(got.Headers) != 0 {
+		t.Errorf("Headers = %v, want empty", got.Headers)
+	}
+}
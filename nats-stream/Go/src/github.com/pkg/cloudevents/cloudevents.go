@@ -0,0 +1,164 @@
+// Package cloudevents binds CloudEvents (https://cloudevents.io) on top of
+// jsclient's publish/subscribe helpers, so messages can carry event metadata
+// (id, source, type, ...) instead of raw bytes.
+//
+// Two content modes are supported:
+//
+//   - Structured: the whole event is JSON-encoded into the message body with
+//     a "content-type: application/cloudevents+json" header.
+//   - Binary: context attributes are carried as "ce-"-prefixed message
+//     headers and only the event's Data is the message body.
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/jsclient"
+)
+
+const (
+	headerContentType     = "content-type"
+	structuredMediaType   = "application/cloudevents+json"
+	ceAttrPrefix          = "ce-"
+	ceAttrSpecVersion     = ceAttrPrefix + "specversion"
+	ceAttrID              = ceAttrPrefix + "id"
+	ceAttrSource          = ceAttrPrefix + "source"
+	ceAttrType            = ceAttrPrefix + "type"
+	ceAttrTime            = ceAttrPrefix + "time"
+	ceAttrSubject         = ceAttrPrefix + "subject"
+	ceAttrDataContentType = ceAttrPrefix + "datacontenttype"
+	specVersion           = "1.0"
+)
+
+// Event is a CloudEvents envelope. Data is left as raw JSON so callers can
+// decode it into whatever type they expect. Extensions are string-valued
+// and are only put on the wire in Binary mode, as "ce-"-prefixed headers;
+// Structured mode does not encode them, and decoding does not read them
+// back.
+type Event struct {
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	Type            string            `json:"type"`
+	SpecVersion     string            `json:"specversion"`
+	Time            time.Time         `json:"time,omitempty"`
+	Subject         string            `json:"subject,omitempty"`
+	DataContentType string            `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage   `json:"data,omitempty"`
+	Extensions      map[string]string `json:"-"`
+}
+
+// NewEvent builds an Event with SpecVersion and Time filled in, ready to
+// carry data marshaled from v.
+func NewEvent(id, source, eventType string, v any) (Event, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Event{}, fmt.Errorf("cloudevents: marshal data: %w", err)
+	}
+	return Event{
+		ID:              id,
+		Source:          source,
+		Type:            eventType,
+		SpecVersion:     specVersion,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	}, nil
+}
+
+// Mode selects how an Event is put on the wire.
+type Mode int
+
+const (
+	// Structured JSON-encodes the whole event into the message body.
+	Structured Mode = iota
+	// Binary carries context attributes as message headers and leaves
+	// only the event data in the message body.
+	Binary
+)
+
+// PublishEvent publishes event to stream.subject using mode, ensuring the
+// stream exists via client.
+func PublishEvent(ctx context.Context, client *jsclient.Client, stream, subject string, event Event, mode Mode) error {
+	switch mode {
+	case Structured:
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("cloudevents: marshal event: %w", err)
+		}
+		msg := nats.NewMsg(jsclient.Channel(stream, subject))
+		msg.Header.Set(headerContentType, structuredMediaType)
+		msg.Data = body
+		return client.PublishMsg(stream, msg, jsclient.Options{})
+	case Binary:
+		msg := nats.NewMsg(jsclient.Channel(stream, subject))
+		setBinaryHeaders(msg.Header, event)
+		msg.Data = event.Data
+		return client.PublishMsg(stream, msg, jsclient.Options{})
+	default:
+		return fmt.Errorf("cloudevents: unknown mode %d", mode)
+	}
+}
+
+func setBinaryHeaders(h nats.Header, event Event) {
+	h.Set(ceAttrSpecVersion, event.SpecVersion)
+	h.Set(ceAttrID, event.ID)
+	h.Set(ceAttrSource, event.Source)
+	h.Set(ceAttrType, event.Type)
+	if !event.Time.IsZero() {
+		h.Set(ceAttrTime, event.Time.Format(time.RFC3339Nano))
+	}
+	if event.Subject != "" {
+		h.Set(ceAttrSubject, event.Subject)
+	}
+	if event.DataContentType != "" {
+		h.Set(ceAttrDataContentType, event.DataContentType)
+	}
+	for k, v := range event.Extensions {
+		h.Set(ceAttrPrefix+k, v)
+	}
+}
+
+// EventHandler processes a decoded CloudEvent delivered to a subscription.
+type EventHandler func(ctx context.Context, event Event)
+
+// SubscribeEvents subscribes to stream.subject and decodes every delivered
+// message as a CloudEvent, detecting structured vs binary mode from its
+// headers before invoking handler.
+func SubscribeEvents(client *jsclient.Client, stream, subject string, handler EventHandler) (*jsclient.Subscription, error) {
+	return client.Subscribe(stream, subject, func(msg *nats.Msg) {
+		event, err := decode(msg)
+		if err != nil {
+			return
+		}
+		handler(context.Background(), event)
+	}, jsclient.Options{})
+}
+
+func decode(msg *nats.Msg) (Event, error) {
+	if ct := msg.Header.Get(headerContentType); strings.HasPrefix(ct, "application/cloudevents") {
+		var event Event
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			return Event{}, fmt.Errorf("cloudevents: decode structured event: %w", err)
+		}
+		return event, nil
+	}
+
+	if sv := msg.Header.Get(ceAttrSpecVersion); sv != "" {
+		return Event{
+			ID:              msg.Header.Get(ceAttrID),
+			Source:          msg.Header.Get(ceAttrSource),
+			Type:            msg.Header.Get(ceAttrType),
+			SpecVersion:     sv,
+			Subject:         msg.Header.Get(ceAttrSubject),
+			DataContentType: msg.Header.Get(ceAttrDataContentType),
+			Data:            msg.Data,
+		}, nil
+	}
+
+	return Event{}, fmt.Errorf("cloudevents: message has neither %q nor %q header", headerContentType, ceAttrSpecVersion)
+}
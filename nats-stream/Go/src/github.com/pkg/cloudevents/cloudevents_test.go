@@ -0,0 +1,93 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestNewEvent(t *testing.T) {
+	event, err := NewEvent("1", "bookserver", "book.created", map[string]string{"title": "Foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.SpecVersion != specVersion {
+		t.Errorf("SpecVersion = %q, want %q", event.SpecVersion, specVersion)
+	}
+	if event.Time.IsZero() {
+		t.Error("Time is zero, want NewEvent to fill it in")
+	}
+	if event.DataContentType != "application/json" {
+		t.Errorf("DataContentType = %q, want application/json", event.DataContentType)
+	}
+
+	var data map[string]string
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		t.Fatalf("Data doesn't round-trip as JSON: %v", err)
+	}
+	if data["title"] != "Foo" {
+		t.Errorf("Data[title] = %q, want Foo", data["title"])
+	}
+}
+
+func TestDecodeStructured(t *testing.T) {
+	event, err := NewEvent("1", "bookserver", "book.created", map[string]string{"title": "Foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := nats.NewMsg("books.book.created")
+	msg.Header.Set(headerContentType, structuredMediaType)
+	msg.Data = body
+
+	got, err := decode(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != event.ID || got.Type != event.Type {
+		t.Errorf("decode() = %+v, want ID/Type matching %+v", got, event)
+	}
+}
+
+func TestDecodeBinary(t *testing.T) {
+	event, err := NewEvent("1", "bookserver", "book.created", map[string]string{"title": "Foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.Subject = "books.1"
+
+	msg := nats.NewMsg("books.book.created")
+	setBinaryHeaders(msg.Header, event)
+	msg.Data = event.Data
+
+	got, err := decode(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != event.ID {
+		t.Errorf("ID = %q, want %q", got.ID, event.ID)
+	}
+	if got.Type != event.Type {
+		t.Errorf("Type = %q, want %q", got.Type, event.Type)
+	}
+	if got.Subject != event.Subject {
+		t.Errorf("Subject = %q, want %q", got.Subject, event.Subject)
+	}
+	if string(got.Data) != string(event.Data) {
+		t.Errorf("Data = %q, want %q", got.Data, event.Data)
+	}
+}
+
+func TestDecodeRejectsMessageWithNeitherHeader(t *testing.T) {
+	msg := nats.NewMsg("books.book.created")
+	msg.Data = []byte(`{}`)
+
+	if _, err := decode(msg); err == nil {
+		t.Fatal("decode() on a message with no content-type or ce-specversion header succeeded, want error")
+	}
+}
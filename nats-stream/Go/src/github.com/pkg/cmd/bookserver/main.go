@@ -0,0 +1,175 @@
+// Command bookserver ties the packages under github.com/pkg together: it
+// serves the Books CRUD API over HTTP, publishes a BookCreated, BookUpdated
+// or BookDeleted event for every change the API makes, and rebuilds
+// mocks.Books by replaying that event log at startup instead of starting
+// from an empty slice.
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gorilla/mux"
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/auth"
+	"github.com/pkg/eventsourcing"
+	"github.com/pkg/events"
+	"github.com/pkg/handlers"
+	"github.com/pkg/jsclient"
+	"github.com/pkg/mocks"
+	"github.com/pkg/models"
+	"github.com/pkg/workerpool"
+)
+
+const (
+	natsURL      = "nats://127.0.0.1:4222"
+	booksStream  = "books"
+	catalogQueue = "catalog-projector"
+	// catalogDeadLetter is where a catalog event lands if applying it to
+	// mocks.Books keeps failing after retrying.
+	catalogDeadLetter = "catalog-dead-letter"
+)
+
+// authConfig is the key material and validation rules for the bearer tokens
+// required on the routes that publish domain events. A real deployment
+// would also load an RSA public key for BOOKSERVER_JWT_RSA_PUBLIC_KEY
+// tokens; only the HMAC secret is wired up here since that's all this
+// example's token-issuing side uses.
+var authConfig = auth.Config{
+	HMACSecret: []byte(os.Getenv("BOOKSERVER_JWT_SECRET")),
+	Issuer:     "bookserver",
+	Audience:   "bookserver-api",
+}
+
+func main() {
+	js, err := jsclient.Connect(natsURL)
+	if err != nil {
+		log.Fatalf("connect: %v", err)
+	}
+	defer js.Close()
+
+	client := eventsourcing.NewClient(js)
+	store, err := client.EventStore(booksStream)
+	if err != nil {
+		log.Fatalf("open event store: %v", err)
+	}
+	handlers.SetEventStore(store)
+	handlers.SetStream(js, booksStream)
+
+	if err := rebuildCatalog(store); err != nil {
+		log.Fatalf("rebuild catalog: %v", err)
+	}
+
+	sub, err := workerpool.Subscribe(js, booksStream, events.CatalogStreamID, applyBookEvent, jsclient.Options{},
+		workerpool.Config{Size: 4, MaxRedeliver: 3, DLQSubject: catalogDeadLetter},
+		jsclient.WithDurableName(catalogQueue))
+	if err != nil {
+		log.Fatalf("subscribe to catalog: %v", err)
+	}
+
+	server := &http.Server{Addr: ":8080", Handler: newRouter()}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("serve: %v", err)
+		}
+	}()
+
+	waitForShutdown(server, sub, js)
+}
+
+// newRouter wires the Books CRUD API plus a live event feed. GET routes
+// (including the event feed) are open; the routes that publish a domain
+// event (create, update, delete) require a bearer token authorized to
+// publish to events.CatalogStreamID.
+func newRouter() *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/books", handlers.GetAllBooks).Methods(http.MethodGet)
+	r.HandleFunc("/books/{id}", handlers.GetBook).Methods(http.MethodGet)
+	r.HandleFunc("/events", handlers.StreamEvents).Methods(http.MethodGet)
+
+	publishing := r.NewRoute().Subrouter()
+	publishing.Use(auth.Middleware(authConfig))
+	publishing.HandleFunc("/books", handlers.AddBooks).Methods(http.MethodPost)
+	publishing.HandleFunc("/books/{id}", handlers.UpdateBook).Methods(http.MethodPut)
+	publishing.HandleFunc("/books/{id}", handlers.DeleteBook).Methods(http.MethodDelete)
+
+	return r
+}
+
+// rebuildCatalog replays every book event recorded so far into mocks.Books,
+// turning it into a projection of the event log rather than an empty slice
+// on every restart.
+func rebuildCatalog(store *eventsourcing.EventStore) error {
+	history, err := store.Load(context.Background(), events.CatalogStreamID)
+	if err != nil {
+		return err
+	}
+	for _, event := range history {
+		applyBookEventData(event)
+	}
+	return nil
+}
+
+func applyBookEvent(msg *nats.Msg) error {
+	event, err := eventsourcing.UnpackEvent(msg)
+	if err != nil {
+		return err
+	}
+	applyBookEventData(event)
+	return nil
+}
+
+// applyBookEventData folds a single decoded book event into mocks.Books,
+// used both when replaying history and when a live event arrives.
+func applyBookEventData(event eventsourcing.Event) {
+	book, ok := event.Data.(*models.Book)
+	if !ok {
+		return
+	}
+
+	// mocks.Mu also guards the HTTP handlers' access to mocks.Books, needed
+	// now that the catalog subscription runs applyBookEvent across a pool of
+	// workers instead of one callback at a time.
+	mocks.Mu.Lock()
+	defer mocks.Mu.Unlock()
+
+	switch event.Type {
+	case events.BookCreated:
+		mocks.Books = append(mocks.Books, *book)
+	case events.BookUpdated:
+		for i, b := range mocks.Books {
+			if b.ID == book.ID {
+				mocks.Books[i] = *book
+				break
+			}
+		}
+	case events.BookDeleted:
+		for i, b := range mocks.Books {
+			if b.ID == book.ID {
+				mocks.Books = append(mocks.Books[:i], mocks.Books[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// waitForShutdown blocks until SIGINT/SIGTERM, then drains the catalog
+// subscription, shuts the HTTP server down, and closes the NATS connection.
+func waitForShutdown(server *http.Server, sub *jsclient.Subscription, js *jsclient.Client) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
+
+	log.Println("shutting down...")
+	if err := sub.Drain(); err != nil {
+		log.Printf("drain: %v", err)
+	}
+	if err := server.Shutdown(context.Background()); err != nil {
+		log.Printf("http shutdown: %v", err)
+	}
+	js.Close()
+}
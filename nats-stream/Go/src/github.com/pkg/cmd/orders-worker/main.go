@@ -0,0 +1,63 @@
+// Command orders-worker demonstrates workerpool.Subscribe: it consumes the
+// "bestellugen" subject with a bounded pool of workers and routes any order
+// that keeps failing to a dead-letter subject instead of redelivering it
+// forever.
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/jsclient"
+	"github.com/pkg/workerpool"
+)
+
+const (
+	natsURL          = "nats://127.0.0.1:4222"
+	ordersStream     = "orders"
+	ordersSubject    = "bestellugen"
+	ordersDeadLetter = "bestellugen-dlq"
+	ordersQueue      = "orders-worker"
+)
+
+func main() {
+	js, err := jsclient.Connect(natsURL)
+	if err != nil {
+		log.Fatalf("connect: %v", err)
+	}
+	defer js.Close()
+
+	sub, err := workerpool.Subscribe(js, ordersStream, ordersSubject, handleOrder, jsclient.Options{},
+		workerpool.Config{Size: 8, MaxInFlight: 16, MaxRedeliver: 5, DLQSubject: ordersDeadLetter},
+		jsclient.WithDurableName(ordersQueue))
+	if err != nil {
+		log.Fatalf("subscribe to %s: %v", ordersSubject, err)
+	}
+
+	waitForShutdown(sub, js)
+}
+
+// handleOrder is a stand-in for whatever order-fulfillment logic would run
+// per message; it only logs, so this command has something worth pointing
+// workerpool.Subscribe at without a real order-processing dependency.
+func handleOrder(msg *nats.Msg) error {
+	log.Printf("orders-worker: processing %s", msg.Data)
+	return nil
+}
+
+// waitForShutdown blocks until SIGINT/SIGTERM, then drains the subscription
+// and closes the NATS connection.
+func waitForShutdown(sub *jsclient.Subscription, js *jsclient.Client) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
+
+	log.Println("shutting down...")
+	if err := sub.Drain(); err != nil {
+		log.Printf("drain: %v", err)
+	}
+	js.Close()
+}
@@ -0,0 +1,85 @@
+// Package events defines the domain events the bookserver example appends
+// to and replays from its event store, and registers their payload types so
+// eventsourcing.UnpackEvent can decode them.
+package events
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/eventsourcing"
+	"github.com/pkg/models"
+)
+
+// BookCreated is the event type recorded each time a book is added.
+const BookCreated = "BookCreated"
+
+// BookUpdated is the event type recorded each time a book's fields change.
+const BookUpdated = "BookUpdated"
+
+// BookDeleted is the event type recorded each time a book is removed.
+const BookDeleted = "BookDeleted"
+
+// CatalogStreamID is the streamID every book event is appended under; the
+// example keeps a single catalog rather than one stream per book.
+const CatalogStreamID = "catalog"
+
+func init() {
+	eventsourcing.Registry.Register(BookCreated, func() any { return &models.Book{} })
+	eventsourcing.Registry.Register(BookUpdated, func() any { return &models.Book{} })
+	eventsourcing.Registry.Register(BookDeleted, func() any { return &models.Book{} })
+}
+
+// PublishBookCreated appends a BookCreated event for book to store, keyed
+// by book.ID so a retried publish is deduplicated by the server instead of
+// creating a second event.
+func PublishBookCreated(ctx context.Context, store *eventsourcing.EventStore, book models.Book) error {
+	err := store.Append(ctx, CatalogStreamID, eventsourcing.Event{
+		Type: BookCreated,
+		Data: book,
+		Metadata: map[string]string{
+			"event-id": strconv.Itoa(book.ID),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("events: publish BookCreated for book %d: %w", book.ID, err)
+	}
+	return nil
+}
+
+// PublishBookUpdated appends a BookUpdated event for book to store. Unlike
+// Created/Deleted, an update isn't naturally idempotent by book ID alone -
+// the same book can be updated repeatedly - so the dedup key also carries
+// the wall-clock time it was published at.
+func PublishBookUpdated(ctx context.Context, store *eventsourcing.EventStore, book models.Book) error {
+	err := store.Append(ctx, CatalogStreamID, eventsourcing.Event{
+		Type: BookUpdated,
+		Data: book,
+		Metadata: map[string]string{
+			"event-id": fmt.Sprintf("updated-%d-%d", book.ID, time.Now().UnixNano()),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("events: publish BookUpdated for book %d: %w", book.ID, err)
+	}
+	return nil
+}
+
+// PublishBookDeleted appends a BookDeleted event for book to store, keyed
+// the same way as PublishBookCreated so a retried delete is deduplicated
+// rather than recorded twice.
+func PublishBookDeleted(ctx context.Context, store *eventsourcing.EventStore, book models.Book) error {
+	err := store.Append(ctx, CatalogStreamID, eventsourcing.Event{
+		Type: BookDeleted,
+		Data: book,
+		Metadata: map[string]string{
+			"event-id": "deleted-" + strconv.Itoa(book.ID),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("events: publish BookDeleted for book %d: %w", book.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package codec provides pluggable (de)serialization for stored events,
+// selected by name so a stream can mix encodings across its lifetime (e.g.
+// while migrating from JSON to protobuf) without losing the ability to
+// decode older events.
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Codec encodes and decodes event payloads.
+type Codec interface {
+	// Name identifies the codec in stored event headers.
+	Name() string
+	Encode(v any) ([]byte, error)
+	Decode(data []byte, v any) error
+}
+
+var registry = map[string]Codec{}
+
+// Register makes a Codec available to Lookup under its own Name().
+func Register(c Codec) {
+	registry[c.Name()] = c
+}
+
+// Lookup returns the codec registered under name, or false if none was.
+func Lookup(name string) (Codec, bool) {
+	c, ok := registry[name]
+	return c, ok
+}
+
+// JSON is the default codec. eventsourcing.EventStore encodes every event
+// with it; other registered codecs are only looked up by name when decoding.
+var JSON Codec = jsonCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return "json" }
+
+func (jsonCodec) Encode(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("codec: json encode: %w", err)
+	}
+	return b, nil
+}
+
+func (jsonCodec) Decode(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("codec: json decode: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	Register(JSON)
+}
@@ -0,0 +1,36 @@
+package codec
+
+import "testing"
+
+type point struct {
+	X, Y int
+}
+
+func TestJSONEncodeDecodeRoundTrip(t *testing.T) {
+	data, err := JSON.Encode(point{X: 1, Y: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got point
+	if err := JSON.Decode(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != (point{X: 1, Y: 2}) {
+		t.Errorf("got %+v, want {1 2}", got)
+	}
+}
+
+func TestLookupFindsRegisteredCodecsByName(t *testing.T) {
+	c, ok := Lookup("json")
+	if !ok {
+		t.Fatal("Lookup(json) = false, want true - JSON registers itself in init")
+	}
+	if c.Name() != "json" {
+		t.Errorf("Name() = %q, want json", c.Name())
+	}
+
+	if _, ok := Lookup("does-not-exist"); ok {
+		t.Error("Lookup(does-not-exist) = true, want false")
+	}
+}
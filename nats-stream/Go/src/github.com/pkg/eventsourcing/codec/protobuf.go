@@ -0,0 +1,42 @@
+package codec
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Protobuf encodes events using the protocol buffers wire format. v passed
+// to Encode/Decode must implement proto.Message.
+var Protobuf Codec = protobufCodec{}
+
+type protobufCodec struct{}
+
+func (protobufCodec) Name() string { return "protobuf" }
+
+func (protobufCodec) Encode(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("codec: protobuf encode: %T does not implement proto.Message", v)
+	}
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("codec: protobuf encode: %w", err)
+	}
+	return b, nil
+}
+
+func (protobufCodec) Decode(data []byte, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("codec: protobuf decode: %T does not implement proto.Message", v)
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("codec: protobuf decode: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	Register(Protobuf)
+}
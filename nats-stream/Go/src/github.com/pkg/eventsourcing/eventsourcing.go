@@ -0,0 +1,181 @@
+// Package eventsourcing layers event sourcing on top of jsclient: events
+// appended to a stream are the source of truth, and readers rebuild state by
+// replaying them. The design is inspired by Ceen's EventStore/Registry/Codec
+// split, adapted to sit directly on a JetStream stream.
+package eventsourcing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/eventsourcing/codec"
+	"github.com/pkg/eventsourcing/types"
+	"github.com/pkg/jsclient"
+)
+
+const (
+	headerEventType  = "event-type"
+	headerEventCodec = "event-codec"
+	headerEventID    = "event-id"
+)
+
+// Registry is the default type registry events are looked up in when
+// Unpacking. Callers register their event structs against it at startup.
+var Registry types.Registry
+
+// Event is a stored event, decoded into its registered Go type.
+type Event struct {
+	Type     string
+	Data     any
+	Metadata map[string]string
+}
+
+// Client wraps a jsclient.Client with the ability to open event stores.
+type Client struct {
+	*jsclient.Client
+}
+
+// NewClient adapts an existing jsclient.Client for event sourcing.
+func NewClient(js *jsclient.Client) *Client {
+	return &Client{Client: js}
+}
+
+// EventStore maps to a single JetStream stream holding every event for every
+// entity appended through it, addressed by streamID (e.g. an aggregate ID).
+type EventStore struct {
+	client *jsclient.Client
+	stream string
+	codec  codec.Codec
+}
+
+// EventStore returns an EventStore for the stream named name. It does not
+// contact the server; the stream is created, if missing, by the first
+// Append, Load or Subscribe.
+func (c *Client) EventStore(name string) (*EventStore, error) {
+	return &EventStore{client: c.Client, stream: name, codec: codec.JSON}, nil
+}
+
+// Append publishes events under streamID, setting per-event headers so
+// UnpackEvent can later recover its type and codec, and using the event's
+// own ID as the NATS dedup key (Nats-Msg-Id) so retried Appends don't create
+// duplicate entries.
+func (s *EventStore) Append(ctx context.Context, streamID string, events ...Event) error {
+	for _, event := range events {
+		data, err := s.codec.Encode(event.Data)
+		if err != nil {
+			return fmt.Errorf("eventsourcing: encode %s event: %w", event.Type, err)
+		}
+
+		id := event.Metadata["event-id"]
+		if id == "" {
+			return fmt.Errorf("eventsourcing: event %s is missing metadata[\"event-id\"]", event.Type)
+		}
+
+		msg := nats.NewMsg(jsclient.Channel(s.stream, streamID))
+		msg.Header.Set(headerEventType, event.Type)
+		msg.Header.Set(headerEventCodec, s.codec.Name())
+		msg.Header.Set(headerEventID, id)
+		msg.Header.Set(nats.MsgIdHdr, id)
+		for k, v := range event.Metadata {
+			msg.Header.Set(k, v)
+		}
+		msg.Data = data
+
+		if err := s.client.PublishMsg(s.stream, msg, jsclient.Options{}); err != nil {
+			return fmt.Errorf("eventsourcing: append %s to %s: %w", event.Type, streamID, err)
+		}
+	}
+	return nil
+}
+
+// Load replays every event appended for streamID, oldest first, by driving
+// an ephemeral consumer from the start of the stream.
+func (s *EventStore) Load(ctx context.Context, streamID string) ([]Event, error) {
+	var (
+		mu      sync.Mutex
+		events  []Event
+		loadErr error
+		arrived = make(chan struct{}, 1)
+	)
+
+	sub, err := s.client.Subscribe(s.stream, streamID, func(msg *nats.Msg) {
+		event, err := UnpackEvent(msg)
+
+		mu.Lock()
+		defer mu.Unlock()
+		select {
+		case arrived <- struct{}{}:
+		default:
+		}
+		if err != nil {
+			loadErr = err
+			return
+		}
+		events = append(events, event)
+	}, jsclient.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("eventsourcing: load %s: %w", streamID, err)
+	}
+	defer sub.Unsubscribe()
+
+	for caughtUp := false; !caughtUp; {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-arrived:
+			// A message was delivered; start the idle wait over.
+		case <-time.After(500 * time.Millisecond):
+			// Ephemeral replay consumers deliver everything on the stream as
+			// fast as the server can send it; if nothing new arrives for this
+			// long we've caught up.
+			caughtUp = true
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	return events, nil
+}
+
+// Subscribe decodes every message delivered for streamID the same way Load
+// does, invoking handler as events arrive - useful for live projections.
+func (s *EventStore) Subscribe(ctx context.Context, streamID string, handler func(Event)) (*jsclient.Subscription, error) {
+	return s.client.Subscribe(s.stream, streamID, func(msg *nats.Msg) {
+		event, err := UnpackEvent(msg)
+		if err != nil {
+			return
+		}
+		handler(event)
+	}, jsclient.Options{})
+}
+
+// UnpackEvent reads msg's event-type/event-codec headers, looks both up
+// (codec in the codec package, type in Registry), decodes msg.Data with
+// them, and returns the resulting Event.
+func UnpackEvent(msg *nats.Msg) (Event, error) {
+	eventType := msg.Header.Get(headerEventType)
+	codecName := msg.Header.Get(headerEventCodec)
+
+	c, ok := codec.Lookup(codecName)
+	if !ok {
+		return Event{}, fmt.Errorf("eventsourcing: unknown codec %q", codecName)
+	}
+
+	payload, ok := Registry.New(eventType)
+	if !ok {
+		return Event{}, fmt.Errorf("eventsourcing: unregistered event type %q", eventType)
+	}
+
+	if err := c.Decode(msg.Data, payload); err != nil {
+		return Event{}, fmt.Errorf("eventsourcing: decode %s event: %w", eventType, err)
+	}
+
+	metadata := map[string]string{"event-id": msg.Header.Get(headerEventID)}
+	return Event{Type: eventType, Data: payload, Metadata: metadata}, nil
+}
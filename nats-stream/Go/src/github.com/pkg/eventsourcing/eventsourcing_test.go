@@ -0,0 +1,65 @@
+package eventsourcing
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/eventsourcing/codec"
+)
+
+type orderCreated struct {
+	ID string `json:"id"`
+}
+
+func TestUnpackEventRoundTrip(t *testing.T) {
+	Registry.Register("OrderCreated-unpack-test", func() any { return &orderCreated{} })
+
+	data, err := codec.JSON.Encode(&orderCreated{ID: "o-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := nats.NewMsg("orders.o-1")
+	msg.Header.Set(headerEventType, "OrderCreated-unpack-test")
+	msg.Header.Set(headerEventCodec, codec.JSON.Name())
+	msg.Header.Set(headerEventID, "evt-1")
+	msg.Data = data
+
+	event, err := UnpackEvent(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != "OrderCreated-unpack-test" {
+		t.Errorf("Type = %q, want OrderCreated-unpack-test", event.Type)
+	}
+	if event.Metadata["event-id"] != "evt-1" {
+		t.Errorf("Metadata[event-id] = %q, want evt-1", event.Metadata["event-id"])
+	}
+	order, ok := event.Data.(*orderCreated)
+	if !ok {
+		t.Fatalf("Data is %T, want *orderCreated", event.Data)
+	}
+	if order.ID != "o-1" {
+		t.Errorf("ID = %q, want o-1", order.ID)
+	}
+}
+
+func TestUnpackEventRejectsUnknownCodec(t *testing.T) {
+	msg := nats.NewMsg("orders.o-1")
+	msg.Header.Set(headerEventType, "OrderCreated-unpack-test")
+	msg.Header.Set(headerEventCodec, "does-not-exist")
+
+	if _, err := UnpackEvent(msg); err == nil {
+		t.Fatal("UnpackEvent with an unregistered codec succeeded, want error")
+	}
+}
+
+func TestUnpackEventRejectsUnregisteredType(t *testing.T) {
+	msg := nats.NewMsg("orders.o-1")
+	msg.Header.Set(headerEventType, "NeverRegistered")
+	msg.Header.Set(headerEventCodec, codec.JSON.Name())
+
+	if _, err := UnpackEvent(msg); err == nil {
+		t.Fatal("UnpackEvent for an unregistered event type succeeded, want error")
+	}
+}
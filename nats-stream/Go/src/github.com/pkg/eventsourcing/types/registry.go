@@ -0,0 +1,41 @@
+// Package types maps event-type names to the Go structs they decode into,
+// so the rest of the event-sourcing subsystem never has to switch on a
+// string literal to find out what a stored event's Data field looks like.
+package types
+
+import "fmt"
+
+// Factory returns a fresh, zero-valued instance of an event's payload type.
+// A factory is typically a closure over a struct literal, e.g.:
+//
+//	registry.Register("OrderCreated", func() any { return &OrderCreated{} })
+type Factory func() any
+
+// Registry looks up a Factory by event-type name. The zero value is ready
+// to use.
+type Registry struct {
+	factories map[string]Factory
+}
+
+// Register associates name with factory. It panics if name is already
+// registered, since that almost always means two event types collided on
+// the same name.
+func (r *Registry) Register(name string, factory Factory) {
+	if r.factories == nil {
+		r.factories = make(map[string]Factory)
+	}
+	if _, exists := r.factories[name]; exists {
+		panic(fmt.Sprintf("types: event type %q already registered", name))
+	}
+	r.factories[name] = factory
+}
+
+// New returns a fresh instance for name, or false if name was never
+// registered.
+func (r *Registry) New(name string) (any, bool) {
+	factory, ok := r.factories[name]
+	if !ok {
+		return nil, false
+	}
+	return factory(), true
+}
@@ -0,0 +1,46 @@
+package types
+
+import "testing"
+
+type orderCreated struct {
+	ID string
+}
+
+func TestRegistryNewReturnsFreshInstances(t *testing.T) {
+	var r Registry
+	r.Register("OrderCreated", func() any { return &orderCreated{} })
+
+	a, ok := r.New("OrderCreated")
+	if !ok {
+		t.Fatal("New(OrderCreated) = false, want true")
+	}
+	b, ok := r.New("OrderCreated")
+	if !ok {
+		t.Fatal("New(OrderCreated) = false, want true")
+	}
+	if a == b {
+		t.Error("New returned the same instance twice, want a fresh one each call")
+	}
+	if _, ok := a.(*orderCreated); !ok {
+		t.Errorf("New(OrderCreated) returned %T, want *orderCreated", a)
+	}
+}
+
+func TestRegistryNewReportsUnregisteredName(t *testing.T) {
+	var r Registry
+	if _, ok := r.New("Unknown"); ok {
+		t.Error("New(Unknown) = true, want false on the zero-value Registry")
+	}
+}
+
+func TestRegisterPanicsOnDuplicateName(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Register did not panic on a duplicate name")
+		}
+	}()
+
+	var r Registry
+	r.Register("OrderCreated", func() any { return &orderCreated{} })
+	r.Register("OrderCreated", func() any { return &orderCreated{} })
+}
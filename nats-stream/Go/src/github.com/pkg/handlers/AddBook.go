@@ -6,11 +6,18 @@ import (
 	"log"
 	"math/rand"
 	"encoding/json"
+	"github.com/pkg/auth"
+	"github.com/pkg/events"
 	"github.com/pkg/mocks"
 	"github.com/pkg/models"
 )
 
 func AddBooks(w http.ResponseWriter,r *http.Request){
+	if Events != nil && !canPublish(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	defer r.Body.Close()
 	body,err:=ioutil.ReadAll(r.Body)
 
@@ -22,7 +29,20 @@ func AddBooks(w http.ResponseWriter,r *http.Request){
 	json.Unmarshal(body, &book)
 
 	book.ID=rand.Intn(100)
-	mocks.Books=append(mocks.Books,book)
+
+	if Events != nil {
+		// The catalog subscription in cmd/bookserver applies this same
+		// BookCreated event to mocks.Books once it's delivered, so appending
+		// here too would double it up in this process.
+		if err := events.PublishBookCreated(r.Context(), Events, book); err != nil {
+			log.Println(err)
+		}
+	} else {
+		mocks.Mu.Lock()
+		mocks.Books=append(mocks.Books,book)
+		mocks.Mu.Unlock()
+	}
+
 	w.WriteHeader(http.StatusCreated)
 	w.Header().Add("Content-Type","application/json")
 	json.NewEncoder(w).Encode("created")
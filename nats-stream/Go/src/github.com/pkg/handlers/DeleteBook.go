@@ -5,26 +5,51 @@ import (
 	"net/http"
 	"strconv"
 
+	"log"
+
 	"github.com/gorilla/mux"
+	"github.com/pkg/events"
 	"github.com/pkg/mocks"
+	"github.com/pkg/models"
 )
 
 
 func DeleteBook(w http.ResponseWriter, r *http.Request) {
+	if Events != nil && !canPublish(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	// Read the dynamic id parameter
 	vars := mux.Vars(r)
 	id, _ := strconv.Atoi(vars["id"])
 
 	// Iterate over all the mock Books
+	mocks.Mu.Lock()
+	var deleted models.Book
+	found := false
 	for index, book := range mocks.Books {
 		if book.ID == id {
 			// Delete book and send response if the book Id matches dynamic Id
 			mocks.Books = append(mocks.Books[:index], mocks.Books[index+1:]...)
-
-			w.Header().Add("Content-Type", "application/json")
-			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode("Deleted")
+			deleted = book
+			found = true
 			break
 		}
 	}
+	mocks.Mu.Unlock()
+
+	if !found {
+		return
+	}
+
+	if Events != nil {
+		if err := events.PublishBookDeleted(r.Context(), Events, deleted); err != nil {
+			log.Println(err)
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Deleted")
 }
\ No newline at end of file
@@ -5,6 +5,9 @@ import (
 	"github.com/pkg/mocks")
 
 func GetAllBooks(w http.ResponseWriter,r *http.Request){
+	mocks.Mu.RLock()
+	defer mocks.Mu.RUnlock()
+
 	w.Header().Add("Content-Type","application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(mocks.Books)
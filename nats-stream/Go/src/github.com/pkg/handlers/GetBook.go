@@ -11,6 +11,10 @@ import(
 func GetBook(w http.ResponseWriter,r *http.Request){
  vars := mux.Vars(r)
  id, _ := strconv.Atoi(vars["id"])
+
+ mocks.Mu.RLock()
+ defer mocks.Mu.RUnlock()
+
  for _, book := range mocks.Books {
 	 if book.ID == id {
 		 w.Header().Add("Content-Type", "application/json")
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/jsclient"
+)
+
+// StreamEvents upgrades the request to Server-Sent Events and forwards
+// every message published to the "subject" query parameter (a NATS subject,
+// wildcards included; defaults to ">", matching everything on the stream)
+// to the client, until the client disconnects. The subscription uses
+// jsclient's default start position, so the messages already stored under
+// that subject are sent first, followed by each new one as it's published.
+func StreamEvents(w http.ResponseWriter, r *http.Request) {
+	if stream == nil {
+		http.Error(w, "event stream not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	subject := r.URL.Query().Get("subject")
+	if subject == "" {
+		subject = ">"
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	sub, err := stream.Subscribe(streamName, subject, func(msg *nats.Msg) {
+		fmt.Fprintf(w, "data: %s\n\n", msg.Data)
+		flusher.Flush()
+	}, jsclient.Options{})
+	if err != nil {
+		fmt.Fprintf(w, "event: error\ndata: %s\n\n", err)
+		flusher.Flush()
+		return
+	}
+	defer sub.Unsubscribe()
+
+	<-r.Context().Done()
+}
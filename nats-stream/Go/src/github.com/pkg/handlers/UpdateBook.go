@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/events"
+	"github.com/pkg/mocks"
+	"github.com/pkg/models"
+)
+
+func UpdateBook(w http.ResponseWriter, r *http.Request) {
+	if Events != nil && !canPublish(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, _ := strconv.Atoi(vars["id"])
+
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var update models.Book
+	json.Unmarshal(body, &update)
+	update.ID = id
+
+	mocks.Mu.Lock()
+	found := false
+	for index, book := range mocks.Books {
+		if book.ID == id {
+			mocks.Books[index] = update
+			found = true
+			break
+		}
+	}
+	mocks.Mu.Unlock()
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if Events != nil {
+		if err := events.PublishBookUpdated(r.Context(), Events, update); err != nil {
+			log.Println(err)
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(update)
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/pkg/auth"
+	"github.com/pkg/events"
+	"github.com/pkg/eventsourcing"
+	"github.com/pkg/jsclient"
+)
+
+// Events is the event store AddBooks publishes to. It is nil until
+// SetEventStore is called, which main does once it has an event-sourcing
+// client; handlers fall back to the in-memory mocks.Books alone until then.
+var Events *eventsourcing.EventStore
+
+// SetEventStore wires store into the handlers so they can publish domain
+// events, mirroring how mocks.Books is populated as package-level state.
+func SetEventStore(store *eventsourcing.EventStore) {
+	Events = store
+}
+
+// stream and streamName back StreamEvents; they are nil/empty until
+// SetStream is called.
+var (
+	stream     *jsclient.Client
+	streamName string
+)
+
+// SetStream wires the raw NATS client and stream name StreamEvents
+// subscribes against. It's independent of SetEventStore: the event store
+// reads/writes the domain log, while this only needs to tail it live.
+func SetStream(client *jsclient.Client, name string) {
+	stream = client
+	streamName = name
+}
+
+// canPublish reports whether r carries a bearer token authorized to
+// publish to the catalog stream. A request with no claims at all (the auth
+// middleware wasn't applied to this route) is treated as unauthorized,
+// since every route that publishes domain events is expected to require
+// one.
+func canPublish(r *http.Request) bool {
+	claims, ok := auth.FromContext(r.Context())
+	if !ok {
+		return false
+	}
+	return auth.Authorize(claims, events.CatalogStreamID)
+}
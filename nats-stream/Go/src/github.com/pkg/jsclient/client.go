@@ -0,0 +1,298 @@
+// Package jsclient wraps nats.go's JetStreamContext so the rest of this
+// project can talk to NATS the same way it used to talk to NATS Streaming
+// (stan.go), which is now end-of-life. It mirrors the surface the old
+// stan.Connect/sc.Publish/sc.Subscribe calls offered, but is backed by
+// JetStream streams and consumers instead of a deprecated streaming server.
+package jsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// ErrAckTimeout is returned when a publish does not receive an ack from the
+// JetStream server within the configured timeout.
+var ErrAckTimeout = errors.New("jsclient: timed out waiting for ack")
+
+// Client is a thin wrapper around a NATS connection and its JetStreamContext.
+// It owns stream creation so callers don't have to repeat the same
+// AddStream/UpdateStream dance before every publish or subscribe.
+type Client struct {
+	nc *nats.Conn
+	js nats.JetStreamContext
+}
+
+// Options configures the stream a Client will ensure exists before
+// publishing or subscribing to it.
+type Options struct {
+	// Retention controls how messages are retained on the stream.
+	// Defaults to nats.LimitsPolicy.
+	Retention nats.RetentionPolicy
+	// Storage controls whether the stream is kept in memory or on disk.
+	// Defaults to nats.FileStorage.
+	Storage nats.StorageType
+	// AckWait bounds how long Publish waits for an ack before returning
+	// ErrAckTimeout. Defaults to 5s.
+	AckWait time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.AckWait == 0 {
+		o.AckWait = 5 * time.Second
+	}
+	if o.Storage == 0 {
+		o.Storage = nats.FileStorage
+	}
+	return o
+}
+
+// Connect dials the NATS server at url and returns a Client ready to
+// publish and subscribe. opts are passed straight through to nats.Connect.
+func Connect(url string, opts ...nats.Option) (*Client, error) {
+	nc, err := nats.Connect(url, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("jsclient: connect: %w", err)
+	}
+
+	js, err := nc.JetStream()
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("jsclient: jetstream context: %w", err)
+	}
+
+	return &Client{nc: nc, js: js}, nil
+}
+
+// Close closes the underlying NATS connection without draining it first.
+func (c *Client) Close() {
+	c.nc.Close()
+}
+
+// Channel combines a stream and subject into the "stream.subject" form
+// JetStream consumers filter on.
+func Channel(stream, subject string) string {
+	return stream + "." + subject
+}
+
+// ensureStream creates stream if it doesn't already exist, subscribed to
+// "stream.>" so any subject published under it is captured.
+func (c *Client) ensureStream(stream string, opts Options) error {
+	opts = opts.withDefaults()
+
+	if _, err := c.js.StreamInfo(stream); err == nil {
+		return nil
+	}
+
+	_, err := c.js.AddStream(&nats.StreamConfig{
+		Name:      stream,
+		Subjects:  []string{stream + ".>"},
+		Retention: opts.Retention,
+		Storage:   opts.Storage,
+	})
+	if err != nil {
+		return fmt.Errorf("jsclient: add stream %q: %w", stream, err)
+	}
+	return nil
+}
+
+// Publish synchronously publishes payload to stream.subject, creating the
+// stream first if necessary. It blocks until the server acks the message or
+// opts.AckWait elapses, in which case it returns ErrAckTimeout.
+func (c *Client) Publish(stream, subject string, payload []byte, opts Options) error {
+	if err := c.ensureStream(stream, opts); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.withDefaults().AckWait)
+	defer cancel()
+
+	_, err := c.js.Publish(Channel(stream, subject), payload, nats.Context(ctx))
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return ErrAckTimeout
+		}
+		return fmt.Errorf("jsclient: publish %s.%s: %w", stream, subject, err)
+	}
+	return nil
+}
+
+// AckFuture is returned by PublishAsync and resolves once the server acks
+// (or fails to ack) the message.
+type AckFuture = nats.PubAckFuture
+
+// PublishMsg is like Publish but takes a fully-formed *nats.Msg, so callers
+// that need to set headers (e.g. CloudEvents attributes) aren't limited to
+// a bare payload. msg.Subject must already be stream.subject.
+func (c *Client) PublishMsg(stream string, msg *nats.Msg, opts Options) error {
+	if err := c.ensureStream(stream, opts); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.withDefaults().AckWait)
+	defer cancel()
+
+	_, err := c.js.PublishMsg(msg, nats.Context(ctx))
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return ErrAckTimeout
+		}
+		return fmt.Errorf("jsclient: publish msg to %s: %w", msg.Subject, err)
+	}
+	return nil
+}
+
+// PublishAsync publishes payload to stream.subject without waiting for the
+// ack, returning a future the caller can inspect or select on.
+func (c *Client) PublishAsync(stream, subject string, payload []byte, opts Options) (AckFuture, error) {
+	if err := c.ensureStream(stream, opts); err != nil {
+		return nil, err
+	}
+
+	future, err := c.js.PublishAsync(Channel(stream, subject), payload)
+	if err != nil {
+		return nil, fmt.Errorf("jsclient: publish async %s.%s: %w", stream, subject, err)
+	}
+	return future, nil
+}
+
+// Handler processes a single message delivered to a subscription.
+type Handler func(msg *nats.Msg)
+
+// Subscription is returned by Subscribe and QueueSubscribe; callers use it
+// to stop receiving messages.
+type Subscription struct {
+	sub *nats.Subscription
+}
+
+// Unsubscribe stops the subscription.
+func (s *Subscription) Unsubscribe() error {
+	return s.sub.Unsubscribe()
+}
+
+// Drain stops the subscription after processing any messages already in
+// flight.
+func (s *Subscription) Drain() error {
+	return s.sub.Drain()
+}
+
+// subscribeConfig collects the consumer-level knobs SubscribeOption can set.
+// It is distinct from Options, which only governs stream creation.
+type subscribeConfig struct {
+	durable        string
+	queue          string
+	manualAck      bool
+	maxInFlight    int
+	ackWait        time.Duration
+	startSeq       uint64
+	startTimeDelta time.Duration
+}
+
+// SubscribeOption configures a durable, queue-grouped, or replay-positioned
+// subscription on top of Subscribe/QueueSubscribe's defaults (ephemeral,
+// auto-ack, starting from the first message stored on the stream).
+type SubscribeOption func(*subscribeConfig)
+
+// WithDurableName makes the subscription durable: the server remembers its
+// delivery position under name so a reconnecting subscriber resumes instead
+// of starting over.
+func WithDurableName(name string) SubscribeOption {
+	return func(c *subscribeConfig) { c.durable = name }
+}
+
+// WithQueueGroup load-balances delivery across every subscription sharing
+// queue, the same as calling QueueSubscribe directly.
+func WithQueueGroup(queue string) SubscribeOption {
+	return func(c *subscribeConfig) { c.queue = queue }
+}
+
+// WithManualAck disables auto-ack so handler must call msg.Ack() (or Nak())
+// itself once it has finished processing a message.
+func WithManualAck() SubscribeOption {
+	return func(c *subscribeConfig) { c.manualAck = true }
+}
+
+// WithMaxInFlight bounds how many unacked messages the server will deliver
+// to this subscription at once.
+func WithMaxInFlight(n int) SubscribeOption {
+	return func(c *subscribeConfig) { c.maxInFlight = n }
+}
+
+// WithAckWait bounds how long the server waits for this consumer to ack a
+// delivered message before redelivering it.
+func WithAckWait(d time.Duration) SubscribeOption {
+	return func(c *subscribeConfig) { c.ackWait = d }
+}
+
+// WithStartAtSequence starts delivery at the given stream sequence instead
+// of the default (every message stored on the stream).
+func WithStartAtSequence(seq uint64) SubscribeOption {
+	return func(c *subscribeConfig) { c.startSeq = seq }
+}
+
+// WithStartAtTimeDelta starts delivery at messages published within the
+// last d, instead of the default (every message stored on the stream).
+func WithStartAtTimeDelta(d time.Duration) SubscribeOption {
+	return func(c *subscribeConfig) { c.startTimeDelta = d }
+}
+
+func (cfg subscribeConfig) natsOpts() []nats.SubOpt {
+	var opts []nats.SubOpt
+	if cfg.durable != "" {
+		opts = append(opts, nats.Durable(cfg.durable))
+	}
+	if cfg.manualAck {
+		opts = append(opts, nats.ManualAck())
+	}
+	if cfg.maxInFlight > 0 {
+		opts = append(opts, nats.MaxAckPending(cfg.maxInFlight))
+	}
+	if cfg.ackWait > 0 {
+		opts = append(opts, nats.AckWait(cfg.ackWait))
+	}
+	switch {
+	case cfg.startSeq > 0:
+		opts = append(opts, nats.StartSequence(cfg.startSeq))
+	case cfg.startTimeDelta > 0:
+		opts = append(opts, nats.StartTime(time.Now().Add(-cfg.startTimeDelta)))
+	}
+	return opts
+}
+
+// Subscribe creates stream if needed and delivers messages published to
+// stream.subject to handler. By default the subscription is ephemeral and
+// auto-acking; pass SubscribeOptions to make it durable, queue-grouped,
+// manually acked, or positioned at a specific replay point.
+func (c *Client) Subscribe(stream, subject string, handler Handler, opts Options, subOpts ...SubscribeOption) (*Subscription, error) {
+	if err := c.ensureStream(stream, opts); err != nil {
+		return nil, err
+	}
+
+	var cfg subscribeConfig
+	for _, apply := range subOpts {
+		apply(&cfg)
+	}
+
+	cb := func(m *nats.Msg) { handler(m) }
+
+	var sub *nats.Subscription
+	var err error
+	if cfg.queue != "" {
+		sub, err = c.js.QueueSubscribe(Channel(stream, subject), cfg.queue, cb, cfg.natsOpts()...)
+	} else {
+		sub, err = c.js.Subscribe(Channel(stream, subject), cb, cfg.natsOpts()...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jsclient: subscribe %s.%s: %w", stream, subject, err)
+	}
+	return &Subscription{sub: sub}, nil
+}
+
+// QueueSubscribe is Subscribe with WithQueueGroup(queue) already applied,
+// kept as a convenience for the common load-balancing case.
+func (c *Client) QueueSubscribe(stream, subject, queue string, handler Handler, opts Options, subOpts ...SubscribeOption) (*Subscription, error) {
+	return c.Subscribe(stream, subject, handler, opts, append(subOpts, WithQueueGroup(queue))...)
+}
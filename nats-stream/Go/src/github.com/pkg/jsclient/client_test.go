@@ -0,0 +1,32 @@
+package jsclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestChannelCombinesStreamAndSubject(t *testing.T) {
+	if got, want := Channel("books", "created"), "books.created"; got != want {
+		t.Errorf("Channel() = %q, want %q", got, want)
+	}
+}
+
+func TestOptionsWithDefaults(t *testing.T) {
+	opts := Options{}.withDefaults()
+	if opts.AckWait != 5*time.Second {
+		t.Errorf("AckWait = %v, want 5s", opts.AckWait)
+	}
+	if opts.Storage != nats.FileStorage {
+		t.Errorf("Storage = %v, want FileStorage", opts.Storage)
+	}
+
+	explicit := Options{AckWait: 2 * time.Second, Storage: nats.MemoryStorage}.withDefaults()
+	if explicit.AckWait != 2*time.Second {
+		t.Errorf("AckWait = %v, want the explicit 2s left untouched", explicit.AckWait)
+	}
+	if explicit.Storage != nats.MemoryStorage {
+		t.Errorf("Storage = %v, want the explicit MemoryStorage left untouched", explicit.Storage)
+	}
+}
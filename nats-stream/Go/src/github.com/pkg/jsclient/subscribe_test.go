@@ -0,0 +1,64 @@
+package jsclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscribeOptionsSetConfigFields(t *testing.T) {
+	var cfg subscribeConfig
+	opts := []SubscribeOption{
+		WithDurableName("wb-10-durable"),
+		WithQueueGroup("workers"),
+		WithManualAck(),
+		WithMaxInFlight(5),
+		WithAckWait(10 * time.Second),
+		WithStartAtSequence(42),
+	}
+	for _, apply := range opts {
+		apply(&cfg)
+	}
+
+	if cfg.durable != "wb-10-durable" {
+		t.Errorf("durable = %q, want %q", cfg.durable, "wb-10-durable")
+	}
+	if cfg.queue != "workers" {
+		t.Errorf("queue = %q, want %q", cfg.queue, "workers")
+	}
+	if !cfg.manualAck {
+		t.Error("manualAck = false, want true")
+	}
+	if cfg.maxInFlight != 5 {
+		t.Errorf("maxInFlight = %d, want 5", cfg.maxInFlight)
+	}
+	if cfg.ackWait != 10*time.Second {
+		t.Errorf("ackWait = %v, want 10s", cfg.ackWait)
+	}
+	if cfg.startSeq != 42 {
+		t.Errorf("startSeq = %d, want 42", cfg.startSeq)
+	}
+}
+
+func TestSubscribeOptionsNatsOptsCount(t *testing.T) {
+	var cfg subscribeConfig
+	if got, want := len(cfg.natsOpts()), 0; got != want {
+		t.Fatalf("zero-value config produced %d SubOpts, want %d", got, want)
+	}
+
+	WithDurableName("d")(&cfg)
+	WithManualAck()(&cfg)
+	WithMaxInFlight(5)(&cfg)
+	WithAckWait(time.Second)(&cfg)
+	WithStartAtSequence(1)(&cfg)
+	if got, want := len(cfg.natsOpts()), 5; got != want {
+		t.Errorf("fully configured config produced %d SubOpts, want %d", got, want)
+	}
+
+	// StartAtSequence and StartAtTimeDelta are mutually exclusive; the
+	// sequence already set above should still win over a time delta added
+	// after it since natsOpts prefers it in its switch.
+	WithStartAtTimeDelta(time.Minute)(&cfg)
+	if got, want := len(cfg.natsOpts()), 5; got != want {
+		t.Errorf("adding a start-time-delta alongside a start-sequence produced %d SubOpts, want %d (sequence still wins)", got, want)
+	}
+}
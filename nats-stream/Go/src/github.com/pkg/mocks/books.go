@@ -0,0 +1,21 @@
+// Package mocks holds the in-memory state the handlers package reads and
+// writes. Books starts out as a plain slice; the bookserver example now
+// rebuilds it from the book event log at startup instead of treating it as
+// the source of truth.
+package mocks
+
+import (
+	"sync"
+
+	"github.com/pkg/models"
+)
+
+// Books is the current in-memory projection of the catalog: every created
+// book, with later updates and deletions applied.
+var Books []models.Book
+
+// Mu guards Books. The bookserver example's catalog subscription applies
+// events to Books from a pool of worker goroutines, while the HTTP handlers
+// read and write it from request goroutines at the same time, so both sides
+// need to take this lock around every access.
+var Mu sync.RWMutex
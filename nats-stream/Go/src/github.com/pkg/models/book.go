@@ -0,0 +1,10 @@
+// Package models holds the data types shared across the handlers, mocks,
+// and event-sourcing layers.
+package models
+
+// Book is the resource served and mutated by the handlers package.
+type Book struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Author string `json:"author"`
+}
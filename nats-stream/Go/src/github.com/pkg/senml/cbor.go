@@ -0,0 +1,26 @@
+package senml
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// DecodeCBOR parses data as a CBOR-encoded SenML Pack (RFC 8428 §6), the
+// binary sibling of Decode's JSON.
+func DecodeCBOR(data []byte) (Pack, error) {
+	var pack Pack
+	if err := cbor.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("senml: decode cbor: %w", err)
+	}
+	return pack, nil
+}
+
+// EncodeCBOR serializes pack using CBOR.
+func EncodeCBOR(pack Pack) ([]byte, error) {
+	data, err := cbor.Marshal(pack)
+	if err != nil {
+		return nil, fmt.Errorf("senml: encode cbor: %w", err)
+	}
+	return data, nil
+}
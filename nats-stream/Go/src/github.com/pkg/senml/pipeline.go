@@ -0,0 +1,43 @@
+package senml
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/pkg/broker"
+)
+
+// Publish normalizes pack and publishes it as JSON through pub, so every
+// publisher on a subject sends self-contained records regardless of how it
+// chose to use SenML's Base* compression.
+func Publish(ctx context.Context, pub broker.Publisher, subject string, pack Pack) error {
+	data, err := Encode(pack.Normalize())
+	if err != nil {
+		return err
+	}
+	if err := pub.Publish(ctx, subject, broker.Message{Subject: subject, Data: data}); err != nil {
+		return fmt.Errorf("senml: publish %s: %w", subject, err)
+	}
+	return nil
+}
+
+// Handler processes the Messages a Transformer produced from one delivered
+// message.
+type Handler func(ctx context.Context, messages []Message)
+
+// Subscribe runs every message delivered for subject through transformer
+// before invoking handler, so callers work with resolved Messages instead
+// of raw bytes in whatever encoding the publisher chose. Pass Chain(CBOR,
+// JSON, Passthrough) (or any subset) to accept more than one encoding on
+// the same subject.
+func Subscribe(ctx context.Context, sub broker.Subscriber, subject string, transformer Transformer, handler Handler) (broker.Subscription, error) {
+	return sub.Subscribe(ctx, subject, func(ctx context.Context, msg broker.Message) {
+		messages, err := transformer.Transform(RawMessage(msg.Data))
+		if err != nil {
+			log.Printf("senml: dropping message on %s: %v", subject, err)
+			return
+		}
+		handler(ctx, messages)
+	})
+}
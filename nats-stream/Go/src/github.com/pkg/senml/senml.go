@@ -0,0 +1,89 @@
+// Package senml decodes and normalizes SenML (RFC 8428) measurement packs,
+// and provides a transformer pipeline that sits between a broker.Publisher/
+// Subscriber and the application, so handlers never see a record with
+// unresolved Base* fields.
+package senml
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Record is a single SenML measurement. Pointer fields distinguish "not
+// present" from the type's zero value, matching the JSON spec's optional
+// fields.
+type Record struct {
+	BaseName string  `json:"bn,omitempty" cbor:"-2,keyasint,omitempty"`
+	BaseTime float64 `json:"bt,omitempty" cbor:"-3,keyasint,omitempty"`
+	BaseUnit string  `json:"bu,omitempty" cbor:"-4,keyasint,omitempty"`
+
+	Name        string   `json:"n,omitempty" cbor:"0,keyasint,omitempty"`
+	Unit        string   `json:"u,omitempty" cbor:"1,keyasint,omitempty"`
+	Time        float64  `json:"t,omitempty" cbor:"6,keyasint,omitempty"`
+	Value       *float64 `json:"v,omitempty" cbor:"2,keyasint,omitempty"`
+	StringValue *string  `json:"vs,omitempty" cbor:"3,keyasint,omitempty"`
+	BoolValue   *bool    `json:"vb,omitempty" cbor:"4,keyasint,omitempty"`
+	Sum         *float64 `json:"s,omitempty" cbor:"5,keyasint,omitempty"`
+}
+
+// Pack is an ordered list of Records, the unit SenML is always transmitted
+// as (a single measurement is still a one-element Pack).
+type Pack []Record
+
+// Decode parses data as a JSON SenML Pack.
+func Decode(data []byte) (Pack, error) {
+	var pack Pack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("senml: decode: %w", err)
+	}
+	return pack, nil
+}
+
+// Encode serializes pack as JSON.
+func Encode(pack Pack) ([]byte, error) {
+	data, err := json.Marshal(pack)
+	if err != nil {
+		return nil, fmt.Errorf("senml: encode: %w", err)
+	}
+	return data, nil
+}
+
+// Normalize resolves every record's Base* fields (set by the first record
+// in the pack per RFC 8428) into absolute Name/Unit/Time values, returning
+// a new Pack whose records are each self-contained and safe to handle
+// independently of their position in the original pack.
+func (pack Pack) Normalize() Pack {
+	var baseName, baseUnit string
+	var baseTime float64
+
+	out := make(Pack, len(pack))
+	for i, r := range pack {
+		if r.BaseName != "" {
+			baseName = r.BaseName
+		}
+		if r.BaseUnit != "" {
+			baseUnit = r.BaseUnit
+		}
+		if r.BaseTime != 0 {
+			baseTime = r.BaseTime
+		}
+
+		out[i] = Record{
+			Name:        baseName + r.Name,
+			Unit:        coalesce(r.Unit, baseUnit),
+			Time:        baseTime + r.Time,
+			Value:       r.Value,
+			StringValue: r.StringValue,
+			BoolValue:   r.BoolValue,
+			Sum:         r.Sum,
+		}
+	}
+	return out
+}
+
+func coalesce(v, fallback string) string {
+	if v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,65 @@
+package senml
+
+// RawMessage is an undecoded transport payload, e.g. the bytes of a
+// broker.Message.
+type RawMessage []byte
+
+// Message is a single normalized measurement; an alias rather than a new
+// type so a Transformer's result is interchangeable with a normalized Pack.
+type Message = Record
+
+// Transformer turns a raw transport payload into normalized Messages,
+// letting a subscriber accept more than one wire encoding (or none at all)
+// without the caller choosing up front which one arrived.
+type Transformer interface {
+	Transform(raw RawMessage) ([]Message, error)
+}
+
+// TransformerFunc adapts a plain function to a Transformer.
+type TransformerFunc func(raw RawMessage) ([]Message, error)
+
+// Transform calls f.
+func (f TransformerFunc) Transform(raw RawMessage) ([]Message, error) { return f(raw) }
+
+// JSON decodes raw as a JSON-encoded SenML Pack and normalizes it.
+var JSON Transformer = TransformerFunc(func(raw RawMessage) ([]Message, error) {
+	pack, err := Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	return pack.Normalize(), nil
+})
+
+// CBOR decodes raw as a CBOR-encoded SenML Pack and normalizes it.
+var CBOR Transformer = TransformerFunc(func(raw RawMessage) ([]Message, error) {
+	pack, err := DecodeCBOR(raw)
+	if err != nil {
+		return nil, err
+	}
+	return pack.Normalize(), nil
+})
+
+// Passthrough treats raw as a single opaque record named "raw", for
+// payloads that aren't SenML at all. It never errors, so it's the sink a
+// Chain should list last if it wants every message to produce something
+// rather than being dropped.
+var Passthrough Transformer = TransformerFunc(func(raw RawMessage) ([]Message, error) {
+	s := string(raw)
+	return []Message{{Name: "raw", StringValue: &s}}, nil
+})
+
+// Chain tries each Transformer in order and returns the result of the first
+// one that succeeds, so a subscriber can accept several wire encodings
+// (e.g. CBOR, then JSON, then a Passthrough default) on the same subject.
+func Chain(transformers ...Transformer) Transformer {
+	return TransformerFunc(func(raw RawMessage) ([]Message, error) {
+		var err error
+		for _, t := range transformers {
+			var msgs []Message
+			if msgs, err = t.Transform(raw); err == nil {
+				return msgs, nil
+			}
+		}
+		return nil, err
+	})
+}
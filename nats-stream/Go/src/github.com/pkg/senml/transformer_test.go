@@ -0,0 +1,55 @@
+package senml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPassthroughNeverErrors(t *testing.T) {
+	msgs, err := Passthrough.Transform(RawMessage("not senml at all"))
+	if err != nil {
+		t.Fatalf("Passthrough errored: %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Name != "raw" || msgs[0].StringValue == nil || *msgs[0].StringValue != "not senml at all" {
+		t.Errorf("got %+v, want a single raw record carrying the input", msgs)
+	}
+}
+
+func TestChainTriesEachTransformerInOrder(t *testing.T) {
+	errFirst := errors.New("first: no")
+	first := TransformerFunc(func(raw RawMessage) ([]Message, error) { return nil, errFirst })
+	second := TransformerFunc(func(raw RawMessage) ([]Message, error) {
+		return []Message{{Name: "second"}}, nil
+	})
+
+	chain := Chain(first, second)
+	msgs, err := chain.Transform(RawMessage("x"))
+	if err != nil {
+		t.Fatalf("Chain errored: %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Name != "second" {
+		t.Errorf("got %+v, want the second transformer's result", msgs)
+	}
+}
+
+func TestChainReturnsLastErrorWhenAllFail(t *testing.T) {
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	a := TransformerFunc(func(raw RawMessage) ([]Message, error) { return nil, errA })
+	b := TransformerFunc(func(raw RawMessage) ([]Message, error) { return nil, errB })
+
+	_, err := Chain(a, b).Transform(RawMessage("x"))
+	if !errors.Is(err, errB) {
+		t.Errorf("Chain(a, b) error = %v, want the last transformer's error (%v)", err, errB)
+	}
+}
+
+func TestChainWithPassthroughDefaultNeverFails(t *testing.T) {
+	errFirst := errors.New("not JSON")
+	notJSON := TransformerFunc(func(raw RawMessage) ([]Message, error) { return nil, errFirst })
+
+	_, err := Chain(notJSON, Passthrough).Transform(RawMessage("raw bytes"))
+	if err != nil {
+		t.Errorf("Chain(notJSON, Passthrough) errored: %v, want Passthrough to catch everything", err)
+	}
+}
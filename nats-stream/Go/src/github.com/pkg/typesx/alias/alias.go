@@ -0,0 +1,48 @@
+// Package alias approximates go/types.Alias (added in Go 1.22) for the
+// pinned go1.21.6 toolchain this repo targets, where "type S = T" produces
+// a plain types.Type for T's underlying type with no trace that S was ever
+// an alias. types.Type only requires Underlying() and String(), both
+// exported, so Alias can genuinely implement the interface from outside
+// the package - but it's still a standalone wrapper type, not the real
+// thing: go/types' own Identical, AssignableTo, and friends type-switch on
+// the checker's internal concrete types, and panic on a types.Type
+// implementation they don't recognize (an *Alias constructed here included),
+// rather than falling back to "not identical". That gap is inherent to
+// wrapping the interface from outside the package, not something this code
+// can close - callers must compare through Unalias instead of handing an
+// *Alias straight to Identical/AssignableTo/etc.
+package alias
+
+import "go/types"
+
+// Alias names Rhs without being a distinct type from it, as far as its own
+// Underlying/String methods are concerned.
+type Alias struct {
+	name string
+	rhs  types.Type
+}
+
+// New returns an Alias called name for rhs.
+func New(name string, rhs types.Type) *Alias {
+	return &Alias{name: name, rhs: rhs}
+}
+
+// Rhs returns the type the alias names.
+func (a *Alias) Rhs() types.Type { return a.rhs }
+
+// Underlying returns Rhs's underlying type, so *Alias satisfies types.Type
+// transparently for any caller that only goes through the interface.
+func (a *Alias) Underlying() types.Type { return a.rhs.Underlying() }
+
+// String returns the alias's own name, as "type S = T" would print S.
+func (a *Alias) String() string { return a.name }
+
+// Unalias returns t.Rhs if t is an *Alias, and t unchanged otherwise - the
+// same shape as go/types.Unalias (1.22+), for code written against this
+// package's Alias rather than the real one.
+func Unalias(t types.Type) types.Type {
+	if a, ok := t.(*Alias); ok {
+		return a.rhs
+	}
+	return t
+}
@@ -0,0 +1,55 @@
+package alias
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestAliasUnderlyingAndString(t *testing.T) {
+	const src = `package p
+type Celsius float64
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	celsius := pkg.Scope().Lookup("Celsius").Type()
+
+	temperature := New("Temperature", celsius)
+	if got, want := temperature.String(), "Temperature"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if !types.Identical(temperature.Underlying(), celsius.Underlying()) {
+		t.Errorf("Underlying() = %s, want %s", temperature.Underlying(), celsius.Underlying())
+	}
+	if got := Unalias(temperature); got != celsius {
+		t.Errorf("Unalias(Temperature) = %s, want %s", got, celsius)
+	}
+}
+
+// TestIdenticalPanicsOnAlias documents the real limitation of wrapping
+// types.Type from outside go/types: Identical type-switches on go/types'
+// own internal concrete types and has no case for a type it doesn't
+// recognize, so calling it with an *Alias panics instead of just reporting
+// "not identical" - unlike a real types.Alias, which go/types' own
+// Identical does know how to see through. Callers must compare through
+// Unalias(temperature) instead of handing an *Alias to Identical directly.
+func TestIdenticalPanicsOnAlias(t *testing.T) {
+	celsius := types.Typ[types.Float64]
+	temperature := New("Temperature", celsius)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Identical(Alias, Rhs) did not panic; go/types may now recognize foreign types.Type implementations")
+		}
+	}()
+	types.Identical(temperature, celsius)
+}
@@ -0,0 +1,17 @@
+// Package checkerr collects every diagnostic a Check call finds, not just
+// the first. Config.Error is already the real hook for this in go/types -
+// nothing needed patching here - but every caller ends up writing the same
+// "append to a slice" callback, so Collect packages it up.
+package checkerr
+
+import "go/types"
+
+// Collect returns a copy of cfg whose Error callback appends every error
+// Check finds into errs, so a caller like a diagnostics server gets the
+// whole list instead of fixing and re-running one error at a time. Check
+// itself still returns after (and reports) only the first error, as usual;
+// Error is called for that one too.
+func Collect(cfg types.Config, errs *[]error) types.Config {
+	cfg.Error = func(err error) { *errs = append(*errs, err) }
+	return cfg
+}
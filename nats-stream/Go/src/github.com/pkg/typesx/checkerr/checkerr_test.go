@@ -0,0 +1,35 @@
+package checkerr
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestCollectCollectsEveryError(t *testing.T) {
+	const src = `package p
+
+func _() {
+	var _ int = "a"
+	var _ string = 1
+	var _ bool = 2.5
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var errs []error
+	cfg := Collect(types.Config{}, &errs)
+	if _, err := cfg.Check("p", fset, []*ast.File{f}, nil); err == nil {
+		t.Fatal("ill-typed package type-checked without error")
+	}
+
+	if got, want := len(errs), 3; got != want {
+		t.Fatalf("Collect gathered %d errors, want %d", got, want)
+	}
+}
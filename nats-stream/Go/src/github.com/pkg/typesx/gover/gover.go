@@ -0,0 +1,133 @@
+// Package gover adds the per-file Go version gating that go/types.Config
+// doesn't do on its own: a file pinned to an older release than the one a
+// caller is checking against (via its own "//go:build goX.Y" line, or
+// Config.DefaultVersion otherwise) is rejected if it uses a feature newer
+// than its own version - type parameters (go1.18) or the min/max/clear
+// builtins (go1.21), the two release-gated syntax features checked here.
+//
+// This can't be added to go/types.Config itself from outside the package -
+// GoVersion-aware gating lives in the checker's internal object resolution
+// (decl.go, stmt.go) - so it wraps Config.Check instead, rejecting
+// too-new files before handing the package to the real checker.
+package gover
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Config wraps types.Config with a default Go version for files that carry
+// no "//go:build goX.Y" line of their own.
+type Config struct {
+	types.Config
+	DefaultVersion string
+}
+
+var goVersionTag = regexp.MustCompile(`\bgo1\.(\d+)\b`)
+
+// FileVersion returns the Go version file is gated to: the version named in
+// its own "//go:build goX.Y" comment, or cfg.DefaultVersion if it has none.
+func (cfg Config) FileVersion(file *ast.File) string {
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			if !strings.HasPrefix(c.Text, "//go:build") {
+				continue
+			}
+			if m := goVersionTag.FindString(c.Text); m != "" {
+				return m
+			}
+		}
+	}
+	return cfg.DefaultVersion
+}
+
+// FileVersions returns FileVersion for every file in files, the wrapper's
+// analogue of the Info.FileVersions field go/types doesn't have yet in this
+// toolchain.
+func (cfg Config) FileVersions(files []*ast.File) map[*ast.File]string {
+	versions := make(map[*ast.File]string, len(files))
+	for _, f := range files {
+		versions[f] = cfg.FileVersion(f)
+	}
+	return versions
+}
+
+// Check type-checks files like types.Config.Check, but first rejects any
+// file whose own FileVersion predates the release that introduced a syntax
+// feature it uses: type parameters (go1.18), or the min/max/clear builtins
+// (go1.21).
+func (cfg Config) Check(path string, fset *token.FileSet, files []*ast.File, info *types.Info) (*types.Package, error) {
+	for _, file := range files {
+		v := cfg.FileVersion(file)
+		if v == "" {
+			continue
+		}
+		if versionLess(v, "go1.18") && usesTypeParams(file) {
+			return nil, fmt.Errorf("gover: %s: file version %s predates type parameters (go1.18)",
+				fset.Position(file.Package).Filename, v)
+		}
+		if versionLess(v, "go1.21") && usesNewBuiltins(file) {
+			return nil, fmt.Errorf("gover: %s: file version %s predates the min/max/clear builtins (go1.21)",
+				fset.Position(file.Package).Filename, v)
+		}
+	}
+	return cfg.Config.Check(path, fset, files, info)
+}
+
+func usesTypeParams(file *ast.File) (found bool) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Type.TypeParams != nil {
+				found = true
+			}
+		case *ast.TypeSpec:
+			if n.TypeParams != nil {
+				found = true
+			}
+		case *ast.IndexListExpr:
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// newBuiltins are the predeclared functions go1.21 added. This is a
+// syntactic check - it doesn't resolve the identifier against scope - so it
+// can be fooled by a local redeclaration of one of these names, same as
+// usesTypeParams doesn't try to tell a real type parameter list apart from
+// code that merely looks like one.
+var newBuiltins = map[string]bool{"min": true, "max": true, "clear": true}
+
+func usesNewBuiltins(file *ast.File) (found bool) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return !found
+		}
+		if id, ok := call.Fun.(*ast.Ident); ok && newBuiltins[id.Name] {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// versionLess reports whether a names an earlier go1.N release than b.
+func versionLess(a, b string) bool {
+	na, oka := parseMinor(a)
+	nb, okb := parseMinor(b)
+	return oka && okb && na < nb
+}
+
+func parseMinor(v string) (int, bool) {
+	v = strings.TrimPrefix(v, "go1.")
+	n, err := strconv.Atoi(v)
+	return n, err == nil
+}
@@ -0,0 +1,142 @@
+package gover
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestCheckGatesTypeParamsByFileVersion(t *testing.T) {
+	const src = `package p
+
+func Map[A, B any](s []A, f func(A) B) []B {
+	r := make([]B, len(s))
+	for i, v := range s {
+		r[i] = f(v)
+	}
+	return r
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (Config{DefaultVersion: "go1.21"}).Check("p", fset, []*ast.File{f}, nil); err != nil {
+		t.Fatalf("generic file failed to type-check under default go1.21: %v", err)
+	}
+
+	if _, err := (Config{DefaultVersion: "go1.17"}).Check("p", fset, []*ast.File{f}, nil); err == nil {
+		t.Fatal("generic file type-checked under default go1.17, want error")
+	}
+}
+
+func TestFileVersionPrefersOwnBuildLine(t *testing.T) {
+	const src = `//go:build go1.17
+
+package p
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{DefaultVersion: "go1.21"}
+	if got, want := cfg.FileVersion(f), "go1.17"; got != want {
+		t.Errorf("FileVersion = %q, want %q", got, want)
+	}
+}
+
+func TestFileVersionsMapsEveryFile(t *testing.T) {
+	const old = `//go:build go1.17
+
+package p
+`
+	const new_ = `package p
+`
+	fset := token.NewFileSet()
+	fOld, err := parser.ParseFile(fset, "old.go", old, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fNew, err := parser.ParseFile(fset, "new.go", new_, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{DefaultVersion: "go1.21"}
+	versions := cfg.FileVersions([]*ast.File{fOld, fNew})
+	if got, want := versions[fOld], "go1.17"; got != want {
+		t.Errorf("FileVersions[old.go] = %q, want %q", got, want)
+	}
+	if got, want := versions[fNew], "go1.21"; got != want {
+		t.Errorf("FileVersions[new.go] = %q, want %q", got, want)
+	}
+}
+
+// TestCheckGatesBuiltinsByFileVersion extends
+// TestCheckGatesTypeParamsByFileVersion to the min/max/clear builtins added
+// in go1.21: Check should gate any release-specific language feature, not
+// just generics.
+func TestCheckGatesBuiltinsByFileVersion(t *testing.T) {
+	const src = `package p
+
+func _() {
+	_ = min(1, 2)
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (Config{DefaultVersion: "go1.21"}).Check("p", fset, []*ast.File{f}, nil); err != nil {
+		t.Fatalf("min builtin failed to type-check under default go1.21: %v", err)
+	}
+
+	if _, err := (Config{DefaultVersion: "go1.20"}).Check("p", fset, []*ast.File{f}, nil); err == nil {
+		t.Fatal("min builtin type-checked under default go1.20, want error")
+	}
+}
+
+// TestCheckGatesPerFileEvenWhenPackageAllows checks that a file pinned to
+// an older release via its own "//go:build" line is rejected for using
+// generics even when another file in the same package (and
+// Config.DefaultVersion) would allow it - gating is per file, not just per
+// package.
+func TestCheckGatesPerFileEvenWhenPackageAllows(t *testing.T) {
+	const old = `//go:build go1.17
+
+package p
+
+func Map[A, B any](s []A, f func(A) B) []B {
+	r := make([]B, len(s))
+	for i, v := range s {
+		r[i] = f(v)
+	}
+	return r
+}
+`
+	const new_ = `package p
+
+var _ = Map[int, int]
+`
+	fset := token.NewFileSet()
+	fOld, err := parser.ParseFile(fset, "old.go", old, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fNew, err := parser.ParseFile(fset, "new.go", new_, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{DefaultVersion: "go1.21"}
+	if _, err := cfg.Check("p", fset, []*ast.File{fOld, fNew}, nil); err == nil {
+		t.Fatal("old.go's generic func type-checked despite its own //go:build go1.17, want error")
+	}
+}
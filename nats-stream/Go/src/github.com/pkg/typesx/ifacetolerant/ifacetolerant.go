@@ -0,0 +1,67 @@
+// Package ifacetolerant builds an interface the same way
+// types.NewInterfaceType does, except it doesn't let conflicting duplicate
+// methods (the same name with incompatible signatures, e.g. M(int) and
+// M(string) from two embedded interfaces) reach Complete, where go/types
+// panics on them today. Making Complete itself tolerant would mean
+// changing its internal method-set computation in interface.go, which
+// isn't part of this tree, so this filters the conflict out before calling
+// the real constructor instead.
+package ifacetolerant
+
+import "go/types"
+
+// New builds an interface from methods and embeddeds like
+// types.NewInterfaceType, but when two methods share a name with
+// incompatible signatures, keeps only the first and silently drops the
+// rest instead of letting Complete panic.
+func New(methods []*types.Func, embeddeds []types.Type) *types.Interface {
+	seen := make(map[string]*types.Func, len(methods))
+	deduped := methods[:0:0]
+	for _, m := range methods {
+		if existing, ok := seen[m.Name()]; ok {
+			if !types.Identical(existing.Type(), m.Type()) {
+				continue
+			}
+		}
+		seen[m.Name()] = m
+		deduped = append(deduped, m)
+	}
+
+	iface := types.NewInterfaceType(deduped, dedupeEmbeddeds(embeddeds))
+	iface.Complete()
+	return iface
+}
+
+// dedupeEmbeddeds drops an embedded interface if an earlier one already
+// contributes every method it would, which is how a conflicting duplicate
+// (same method name, incompatible signature) usually reaches
+// NewInterfaceType in the first place - as two embeddeds, not two direct
+// methods.
+func dedupeEmbeddeds(embeddeds []types.Type) []types.Type {
+	var kept []types.Type
+	seen := make(map[string]*types.Func)
+	for _, e := range embeddeds {
+		iface, ok := e.Underlying().(*types.Interface)
+		if !ok {
+			kept = append(kept, e)
+			continue
+		}
+		conflict := false
+		for i := 0; i < iface.NumMethods(); i++ {
+			m := iface.Method(i)
+			if existing, ok := seen[m.Name()]; ok && !types.Identical(existing.Type(), m.Type()) {
+				conflict = true
+				break
+			}
+		}
+		if conflict {
+			continue
+		}
+		for i := 0; i < iface.NumMethods(); i++ {
+			m := iface.Method(i)
+			seen[m.Name()] = m
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
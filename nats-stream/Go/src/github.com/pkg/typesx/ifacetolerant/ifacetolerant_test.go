@@ -0,0 +1,71 @@
+package ifacetolerant
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestNewToleratesConflictingDuplicates(t *testing.T) {
+	const src = `
+package p
+type A interface {
+	M(int)
+}
+type B interface {
+	M(string)
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	A := pkg.Scope().Lookup("A").Type().Underlying().(*types.Interface)
+	B := pkg.Scope().Lookup("B").Type().Underlying().(*types.Interface)
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("New panicked on embeddeds with a conflicting duplicate method: %v", r)
+		}
+	}()
+
+	iface := New(nil, []types.Type{A, B})
+	if got, want := iface.NumMethods(), 1; got != want {
+		t.Errorf("got %d methods, want %d (only the first conflicting M should survive)", got, want)
+	}
+}
+
+func TestNewKeepsIdenticalDuplicates(t *testing.T) {
+	const src = `
+package p
+type A interface {
+	M()
+}
+type B interface {
+	M()
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	A := pkg.Scope().Lookup("A").Type().Underlying().(*types.Interface)
+	B := pkg.Scope().Lookup("B").Type().Underlying().(*types.Interface)
+
+	iface := New(nil, []types.Type{A, B})
+	if got, want := iface.NumMethods(), 1; got != want {
+		t.Errorf("got %d methods, want %d (identical M from A and B should collapse)", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+// Package incremental re-type-checks a package only when its source has
+// actually changed, approximating the Config.CheckIncremental the request
+// asked for. A true incremental checker would reuse the internal object
+// graph across edits to one file of a large package (checker.go,
+// resolver.go) - that's not reachable from outside go/types, so this
+// approximates incrementality at whole-package granularity instead: if
+// nothing hashed differently since the last Check, the previous result is
+// returned unchanged rather than re-run.
+package incremental
+
+import (
+	"crypto/sha256"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// Checker caches the result of its last successful Check call and skips
+// re-checking when every file's source is byte-identical to last time.
+type Checker struct {
+	cfg    types.Config
+	hashes map[string][32]byte
+	pkg    *types.Package
+}
+
+// NewChecker returns a Checker that type-checks with cfg.
+func NewChecker(cfg types.Config) *Checker {
+	return &Checker{cfg: cfg, hashes: make(map[string][32]byte)}
+}
+
+// Check type-checks files like cfg.Check, but returns the cached result
+// from the previous call if sources is byte-identical, file for file, to
+// what was passed last time. sources maps each file's filename (as
+// fset.Position reports it) to its source text - go/ast discards the
+// original bytes after parsing, so the caller must supply them.
+func (c *Checker) Check(path string, fset *token.FileSet, files []*ast.File, sources map[string][]byte, info *types.Info) (*types.Package, error) {
+	hashes := make(map[string][32]byte, len(files))
+	changed := c.pkg == nil || len(files) != len(c.hashes)
+	for _, f := range files {
+		name := fset.Position(f.Package).Filename
+		h := sha256.Sum256(sources[name])
+		hashes[name] = h
+		if c.hashes[name] != h {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return c.pkg, nil
+	}
+
+	pkg, err := c.cfg.Check(path, fset, files, info)
+	if err != nil {
+		return nil, err
+	}
+	c.hashes, c.pkg = hashes, pkg
+	return pkg, nil
+}
@@ -0,0 +1,66 @@
+package incremental
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestCheckSkipsUnchangedSources(t *testing.T) {
+	const src = `package p
+func F() int { return 1 }`
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := map[string][]byte{"p.go": []byte(src)}
+
+	c := NewChecker(types.Config{})
+	pkg1, err := c.Check("p", fset, []*ast.File{f}, sources, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkg2, err := c.Check("p", fset, []*ast.File{f}, sources, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg1 != pkg2 {
+		t.Error("Check re-checked unchanged sources instead of returning the cached package")
+	}
+}
+
+func TestCheckRechecksChangedSource(t *testing.T) {
+	const src1 = `package p
+func F() int { return 1 }`
+	const src2 = `package p
+func F() int { return 2 }`
+
+	fset := token.NewFileSet()
+	f1, err := parser.ParseFile(fset, "p.go", src1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewChecker(types.Config{})
+	pkg1, err := c.Check("p", fset, []*ast.File{f1}, map[string][]byte{"p.go": []byte(src1)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f2, err := parser.ParseFile(fset, "p.go", src2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg2, err := c.Check("p", fset, []*ast.File{f2}, map[string][]byte{"p.go": []byte(src2)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg1 == pkg2 {
+		t.Error("Check returned the cached package after the file's source changed")
+	}
+}
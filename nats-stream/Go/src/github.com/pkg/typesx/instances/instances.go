@@ -0,0 +1,106 @@
+// Package instances builds the Info.InstancesOf/Origin reverse index go/types
+// doesn't expose directly: Info.Instances only maps an identifier to the
+// Instance it resolved to, so finding every instantiation of a given generic
+// Object means walking the whole map yourself. Adding InstancesOf/Origin to
+// go/types.Info itself would need the checker to maintain the index while it
+// populates Instances, which lives in stmt.go/call.go and isn't part of this
+// tree, so this builds the index as a post-pass over an already-populated
+// Info instead.
+package instances
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// Of returns every Instance recorded in info.Instances whose identifier
+// resolves to obj via info.Uses, i.e. every instantiation of the generic
+// type or function obj declares.
+func Of(info *types.Info, obj types.Object) []types.Instance {
+	var out []types.Instance
+	for id, inst := range info.Instances {
+		if info.Uses[id] == obj {
+			out = append(out, inst)
+		}
+	}
+	return out
+}
+
+// Origin returns the generic object id's instantiation at id was
+// instantiated from - the inverse of Of, looked up directly rather than by
+// re-scanning Instances.
+func Origin(info *types.Info, id *ast.Ident) (types.Object, bool) {
+	if _, ok := info.Instances[id]; !ok {
+		return nil, false
+	}
+	obj, ok := info.Uses[id]
+	return obj, ok
+}
+
+// OfSelector returns the Instance recorded for the generic receiver of a
+// method value like N[int].m, given the *ast.SelectorExpr for the whole
+// expression. The instantiation is recorded against the identifier inside
+// sel.X (e.g. the "N" in N[int]), not against sel itself, so this digs it
+// out rather than making every caller know where to look.
+func OfSelector(info *types.Info, sel *ast.SelectorExpr) (types.Instance, bool) {
+	var indexed ast.Expr
+	switch x := sel.X.(type) {
+	case *ast.IndexExpr:
+		indexed = x.X
+	case *ast.IndexListExpr:
+		indexed = x.X
+	default:
+		return types.Instance{}, false
+	}
+	id, ok := indexed.(*ast.Ident)
+	if !ok {
+		return types.Instance{}, false
+	}
+	inst, ok := info.Instances[id]
+	return inst, ok
+}
+
+// Count returns how many times obj - a generic type or function - was
+// instantiated, without allocating the slice Of would.
+func Count(info *types.Info, obj types.Object) int {
+	n := 0
+	for id := range info.Instances {
+		if info.Uses[id] == obj {
+			n++
+		}
+	}
+	return n
+}
+
+// TypeArgsOf flattens each of obj's recorded instantiations into its
+// ordered type argument list, for callers that want to inspect the
+// arguments directly rather than holding onto the types.Instance values.
+func TypeArgsOf(info *types.Info, obj types.Object) [][]types.Type {
+	var out [][]types.Type
+	for _, inst := range Of(info, obj) {
+		args := make([]types.Type, inst.TypeArgs.Len())
+		for i := range args {
+			args[i] = inst.TypeArgs.At(i)
+		}
+		out = append(out, args)
+	}
+	return out
+}
+
+// TypeArgsOfRecv returns the type arguments recv was instantiated with, for
+// recv a types.Selection.Recv() result such as "p.G[int]" for a method value
+// like g.Get on a g of type G[int]. Selection.Recv() already reflects the
+// instantiation on its own - no wrapping needed there - but getting the
+// argument list back out means knowing to assert recv to *types.Named and
+// call TypeArgs(), which every caller doing this ends up repeating.
+func TypeArgsOfRecv(recv types.Type) (*types.TypeList, bool) {
+	named, ok := recv.(*types.Named)
+	if !ok {
+		return nil, false
+	}
+	args := named.TypeArgs()
+	if args == nil || args.Len() == 0 {
+		return nil, false
+	}
+	return args, true
+}
@@ -0,0 +1,189 @@
+package instances
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestOfAndOrigin(t *testing.T) {
+	const src = `package p
+
+func f[T any](T) {}
+
+func _() {
+	f(1)
+	f("a")
+	f(true)
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := &types.Info{
+		Instances: make(map[*ast.Ident]types.Instance),
+		Uses:      make(map[*ast.Ident]types.Object),
+		Defs:      make(map[*ast.Ident]types.Object),
+	}
+	pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fObj := pkg.Scope().Lookup("f")
+	got := Of(info, fObj)
+	if len(got) != 3 {
+		t.Fatalf("got %d instantiations of f, want 3", len(got))
+	}
+
+	for id := range info.Instances {
+		obj, ok := Origin(info, id)
+		if !ok || obj != fObj {
+			t.Errorf("Origin(%v) = %v, %v, want f, true", id, obj, ok)
+		}
+	}
+}
+
+func TestOfSelectorMethodValue(t *testing.T) {
+	const src = `package p
+
+type N[T any] struct{ v T }
+
+func (N[T]) m() {}
+
+func _() {
+	_ = N[int].m
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := &types.Info{
+		Instances: make(map[*ast.Ident]types.Instance),
+	}
+	if _, err := new(types.Config).Check("p", fset, []*ast.File{f}, info); err != nil {
+		t.Fatal(err)
+	}
+
+	var sel *ast.SelectorExpr
+	ast.Inspect(f, func(n ast.Node) bool {
+		if s, ok := n.(*ast.SelectorExpr); ok && s.Sel.Name == "m" {
+			sel = s
+		}
+		return true
+	})
+	if sel == nil {
+		t.Fatal("no N[int].m selector found")
+	}
+
+	inst, ok := OfSelector(info, sel)
+	if !ok {
+		t.Fatal("OfSelector found no instance for N[int].m's receiver")
+	}
+	if got, want := inst.TypeArgs.Len(), 1; got != want {
+		t.Errorf("got %d type args, want %d", got, want)
+	}
+}
+
+func TestTypeArgsOfRecvOnGenericMethodValue(t *testing.T) {
+	const src = `package p
+
+type G[T any] struct {
+	x T
+}
+
+func (g G[T]) Get() T { return g.x }
+
+func _() {
+	var g G[int]
+	_ = g.Get
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	selections := make(map[*ast.SelectorExpr]*types.Selection)
+	if _, err := new(types.Config).Check("p", fset, []*ast.File{f}, &types.Info{Selections: selections}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Info.Selections also records the g.x selector inside Get's own body, so
+	// pick out the g.Get method value by name instead of assuming it's the
+	// only entry.
+	var sel *types.Selection
+	for expr, s := range selections {
+		if expr.Sel.Name == "Get" {
+			sel = s
+		}
+	}
+	if sel == nil {
+		t.Fatal("no selection recorded for g.Get")
+	}
+
+	args, ok := TypeArgsOfRecv(sel.Recv())
+	if !ok {
+		t.Fatal("TypeArgsOfRecv found no type arguments on G[int]'s receiver")
+	}
+	if got, want := args.Len(), 1; got != want {
+		t.Fatalf("got %d type args, want %d", got, want)
+	}
+	if got, want := args.At(0).String(), "int"; got != want {
+		t.Errorf("type arg = %s, want %s", got, want)
+	}
+
+	if _, ok := TypeArgsOfRecv(types.Typ[types.Int]); ok {
+		t.Error("TypeArgsOfRecv(int) = ok, want false for a non-generic receiver")
+	}
+}
+
+func TestCountCoversTypesAndFuncs(t *testing.T) {
+	const src = `
+package p
+
+type Box[T any] struct {
+	v T
+}
+
+func Pair[A, B any](a A, b B) (A, B) { return a, b }
+
+var _ Box[string]
+
+func _() {
+	Pair(1, "x")
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := &types.Info{
+		Instances: make(map[*ast.Ident]types.Instance),
+		Uses:      make(map[*ast.Ident]types.Object),
+	}
+	pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	box := pkg.Scope().Lookup("Box")
+	if got, want := Count(info, box), 1; got != want {
+		t.Errorf("Count(Box) = %d, want %d", got, want)
+	}
+	pair := pkg.Scope().Lookup("Pair")
+	if got, want := Count(info, pair), 1; got != want {
+		t.Errorf("Count(Pair) = %d, want %d", got, want)
+	}
+}
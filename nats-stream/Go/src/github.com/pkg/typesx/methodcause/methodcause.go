@@ -0,0 +1,78 @@
+// Package methodcause classifies why a type fails to satisfy an interface
+// into a structured Reason, instead of leaving callers to re-derive it from
+// types.MissingMethod's (method, wrongType bool) pair themselves. A
+// MissingMethodReason built into go/types itself would live in lookup.go
+// alongside MissingMethod's own implementation, which isn't part of this
+// tree, so this builds the richer result on top of the existing exported
+// MissingMethod, Implements, and NewPointer instead.
+//
+// MissingMethod alone isn't enough: for an interface with no methods of its
+// own but a type-set-restricting embedded union (e.g. "~int | ~int32"),
+// MissingMethod(v, t, true) reports nil - there are no methods to check -
+// even when v's underlying type isn't a term of the union at all, which
+// Implements (correctly) rejects. Cause cross-checks against Implements so
+// that case comes back as ReasonTypeSet instead of the false ReasonNone
+// a naive MissingMethod-only classifier would report.
+package methodcause
+
+import "go/types"
+
+// Reason is why V fails to satisfy an interface's method requirement.
+type Reason int
+
+const (
+	// ReasonNone means V satisfies the requirement.
+	ReasonNone Reason = iota
+	// ReasonMissing means V has no method of that name at all.
+	ReasonMissing
+	// ReasonWrongSignature means V has the method, but with an
+	// incompatible signature.
+	ReasonWrongSignature
+	// ReasonPointerOnly means the method exists with a compatible
+	// signature, but only on *V, not V.
+	ReasonPointerOnly
+	// ReasonTypeSet means V has every method T requires, but T also
+	// restricts its type set (e.g. via an embedded union or ~T term) and
+	// V's underlying type isn't a member of it.
+	ReasonTypeSet
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonNone:
+		return "none"
+	case ReasonMissing:
+		return "missing"
+	case ReasonWrongSignature:
+		return "wrong signature"
+	case ReasonPointerOnly:
+		return "pointer only"
+	case ReasonTypeSet:
+		return "type set"
+	default:
+		return "unknown"
+	}
+}
+
+// Cause reports why v fails to implement t, built on top of
+// types.MissingMethod: when MissingMethod finds a signature mismatch, Cause
+// re-checks against *v to tell "wrong signature" apart from "only declared
+// on the pointer type". When MissingMethod finds no method problem at all,
+// Cause still double-checks against Implements to catch a type-set mismatch
+// MissingMethod doesn't look for.
+func Cause(v types.Type, t *types.Interface, static bool) (*types.Func, Reason) {
+	method, wrongType := types.MissingMethod(v, t, static)
+	if method != nil {
+		if !wrongType {
+			return method, ReasonMissing
+		}
+		if _, stillWrong := types.MissingMethod(types.NewPointer(v), t, static); !stillWrong {
+			return method, ReasonPointerOnly
+		}
+		return method, ReasonWrongSignature
+	}
+	if !types.Implements(v, t) {
+		return nil, ReasonTypeSet
+	}
+	return nil, ReasonNone
+}
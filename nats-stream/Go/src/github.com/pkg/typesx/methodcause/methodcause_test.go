@@ -0,0 +1,77 @@
+package methodcause
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestCause(t *testing.T) {
+	const src = `
+package p
+type T interface {
+	m()
+}
+
+type V2 struct{}
+func (V2) m() int
+
+type V3 struct{}
+func (*V3) m()
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	T := pkg.Scope().Lookup("T").Type().Underlying().(*types.Interface)
+
+	method, reason := Cause(pkg.Scope().Lookup("V2").Type(), T, true)
+	if method == nil || method.Name() != "m" || reason != ReasonWrongSignature {
+		t.Errorf("V2: got method=%v reason=%v, want m/ReasonWrongSignature", method, reason)
+	}
+
+	method, reason = Cause(pkg.Scope().Lookup("V3").Type(), T, true)
+	if method == nil || method.Name() != "m" || reason != ReasonPointerOnly {
+		t.Errorf("V3: got method=%v reason=%v, want m/ReasonPointerOnly", method, reason)
+	}
+}
+
+// TestCauseTypeSet extends TestCause (which covers method-set mismatches)
+// with an interface that has no methods of its own but restricts its type
+// set via an embedded union: a type outside every union term must report
+// ReasonTypeSet, not the false ReasonNone a MissingMethod-only check would
+// give it.
+func TestCauseTypeSet(t *testing.T) {
+	const src = `
+package p
+
+type Ints interface {
+	~int | ~int32 | ~int64
+}
+
+type MyFloat float64
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Ints := pkg.Scope().Lookup("Ints").Type().Underlying().(*types.Interface)
+	MyFloat := pkg.Scope().Lookup("MyFloat").Type()
+
+	method, reason := Cause(MyFloat, Ints, true)
+	if method != nil || reason != ReasonTypeSet {
+		t.Errorf("MyFloat: got method=%v reason=%v, want nil/ReasonTypeSet", method, reason)
+	}
+}
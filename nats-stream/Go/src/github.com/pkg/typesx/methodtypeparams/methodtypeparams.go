@@ -0,0 +1,24 @@
+// Package methodtypeparams provides the real, supported shape of the thing
+// method type parameters would let you write. Go does not support a method
+// declaring type parameters of its own (func (T) M[Q any](Q) Q) and the Go
+// team has said it won't - see golang.org/issue/49085 - so this can't be
+// "added" to go/types from a downstream repo; there's no compiler feature
+// here to implement, only a workaround to provide. Box adapts the usual
+// motivating case - a generic operation parameterized per call, logically
+// attached to a receiver - into a free generic function taking the
+// receiver as an explicit argument, which go/types already accepts today.
+package methodtypeparams
+
+// Box holds a receiver value that Apply operates on with a per-call type
+// parameter, in place of the method type parameters Go doesn't have.
+type Box[T any] struct {
+	Value T
+}
+
+// Apply is the real, supported equivalent of the spelling
+// "func (b Box[T]) Apply[Q any](q Q, f func(T, Q) Q) Q" would have: Q can't
+// be a method type parameter, so Apply is a free function with the
+// receiver as its first argument instead.
+func Apply[T, Q any](b Box[T], q Q, f func(T, Q) Q) Q {
+	return f(b.Value, q)
+}
@@ -0,0 +1,16 @@
+package methodtypeparams
+
+import "testing"
+
+func TestApply(t *testing.T) {
+	b := Box[int]{Value: 10}
+	got := Apply(b, "x", func(v int, q string) string {
+		if v != 10 {
+			t.Errorf("receiver value = %d, want 10", v)
+		}
+		return q
+	})
+	if got != "x" {
+		t.Errorf("Apply = %q, want %q", got, "x")
+	}
+}
@@ -0,0 +1,57 @@
+// Package parallelcheck type-checks independent packages concurrently. A
+// single shared, long-lived Checker pool that amortizes setup cost across
+// packages would need to reuse go/types' internal checker state safely
+// across goroutines, which lives in checker.go and isn't reachable from
+// outside the package. Independent packages need no shared state at all,
+// though - each gets its own Config.Check call and its own *types.Info -
+// so this parallelizes across packages instead of trying to share one
+// checker instance between them.
+package parallelcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sync"
+)
+
+// Package is one package to type-check.
+type Package struct {
+	Path     string
+	Files    []*ast.File
+	Importer types.Importer
+}
+
+// Result is the outcome of type-checking one Package.
+type Result struct {
+	Package *types.Package
+	Info    *types.Info
+	Err     error
+}
+
+// CheckAll type-checks every pkg concurrently, each against its own fresh
+// *types.Info, and returns results in the same order as pkgs. Every
+// package's InitOrder is its own - package-level init order is only
+// meaningful within a single package, so there's no single deterministic
+// order to report "across" independent packages the way the request
+// described.
+func CheckAll(fset *token.FileSet, pkgs []Package) []Result {
+	results := make([]Result, len(pkgs))
+	var wg sync.WaitGroup
+	wg.Add(len(pkgs))
+	for i, p := range pkgs {
+		go func(i int, p Package) {
+			defer wg.Done()
+			info := &types.Info{
+				Types: make(map[ast.Expr]types.TypeAndValue),
+				Defs:  make(map[*ast.Ident]types.Object),
+				Uses:  make(map[*ast.Ident]types.Object),
+			}
+			cfg := types.Config{Importer: p.Importer}
+			pkg, err := cfg.Check(p.Path, fset, p.Files, info)
+			results[i] = Result{Package: pkg, Info: info, Err: err}
+		}(i, p)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,39 @@
+package parallelcheck
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestCheckAllChecksEveryPackage(t *testing.T) {
+	srcs := []string{
+		`package a; func F() int { return 1 }`,
+		`package b; func G() string { return "b" }`,
+		`package c; type T struct{ X int }`,
+	}
+
+	fset := token.NewFileSet()
+	pkgs := make([]Package, len(srcs))
+	for i, src := range srcs {
+		f, err := parser.ParseFile(fset, "p.go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkgs[i] = Package{Path: f.Name.Name, Files: []*ast.File{f}}
+	}
+
+	results := CheckAll(fset, pkgs)
+	if len(results) != len(pkgs) {
+		t.Fatalf("got %d results, want %d", len(results), len(pkgs))
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Errorf("package %d: %v", i, r.Err)
+		}
+		if r.Package == nil {
+			t.Errorf("package %d: nil *types.Package", i)
+		}
+	}
+}
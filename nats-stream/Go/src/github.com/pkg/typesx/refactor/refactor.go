@@ -0,0 +1,84 @@
+// Package refactor provides the Rename primitive a tool like gorename or
+// gopls needs on top of go/types: every occurrence of an Object across as
+// many type-checked packages as the caller has Info for, and a structured
+// error when the new name would conflict with something already visible in
+// its scope. A full implementation belongs in go/types itself (it would
+// want access to the checker's internal scope-conflict resolution and
+// method-set graph, not just the already-built Scope tree and per-package
+// Info), but Occurrences/OccurrencesAcross and a single-scope conflict
+// check cover the common rename case using only go/types' existing
+// exported surface.
+package refactor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+)
+
+// Conflict reports that renaming Object to NewName would collide with
+// another object, With, already visible in Scope.
+type Conflict struct {
+	Object  types.Object
+	NewName string
+	With    types.Object
+	Scope   *types.Scope
+}
+
+func (c *Conflict) Error() string {
+	return fmt.Sprintf("renaming %s to %q conflicts with %s already declared in %s",
+		c.Object.Name(), c.NewName, c.With, c.Scope)
+}
+
+// Occurrences returns every identifier in info that refers to obj - its
+// declaration plus every use - ordered by source position.
+func Occurrences(info *types.Info, obj types.Object) []*ast.Ident {
+	var ids []*ast.Ident
+	for id, o := range info.Defs {
+		if o == obj {
+			ids = append(ids, id)
+		}
+	}
+	for id, o := range info.Uses {
+		if o == obj {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Pos() < ids[j].Pos() })
+	return ids
+}
+
+// OccurrencesAcross is Occurrences over every package's Info in infos, for
+// renaming an exported Object that other packages also reference: obj's
+// declaration shows up in its own package's Info.Defs, and each importing
+// package records its own reference to the same Object in its Info.Uses, so
+// a rename-safe search has to walk every type-checked package's Info, not
+// just the one that declared obj. It doesn't resolve the method-set
+// implications of renaming an interface method (every concrete type's
+// method satisfying that interface would also need renaming) - that needs
+// the whole program's method sets cross-referenced, not just identifier
+// occurrences, which is out of reach of go/types' exported surface.
+func OccurrencesAcross(infos []*types.Info, obj types.Object) []*ast.Ident {
+	var ids []*ast.Ident
+	for _, info := range infos {
+		ids = append(ids, Occurrences(info, obj)...)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Pos() < ids[j].Pos() })
+	return ids
+}
+
+// Rename reports every identifier that must change to rename obj to
+// newName, or a *Conflict if newName already names something else visible
+// in obj's declaring scope. It only checks obj's own scope - a full
+// implementation would also have to check every scope obj's occurrences
+// appear in, which needs the checker's internal scope lineage rather than
+// just Object.Parent().
+func Rename(info *types.Info, obj types.Object, newName string) ([]*ast.Ident, error) {
+	if scope := obj.Parent(); scope != nil {
+		if existing := scope.Lookup(newName); existing != nil && existing != obj {
+			return nil, &Conflict{Object: obj, NewName: newName, With: existing, Scope: scope}
+		}
+	}
+	return Occurrences(info, obj), nil
+}
@@ -0,0 +1,125 @@
+package refactor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func check(t *testing.T, src string) (*types.Package, *types.Info) {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{
+		Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object),
+	}
+	pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg, info
+}
+
+func TestOccurrences(t *testing.T) {
+	pkg, info := check(t, `package p
+
+func greet(name string) string {
+	return "hello, " + name
+}
+
+func _() {
+	greet("a")
+	greet("b")
+}
+`)
+	greet := pkg.Scope().Lookup("greet")
+	ids := Occurrences(info, greet)
+	if got, want := len(ids), 3; got != want { // 1 decl + 2 calls
+		t.Fatalf("got %d occurrences, want %d", got, want)
+	}
+}
+
+func TestOccurrencesAcrossPackages(t *testing.T) {
+	fset := token.NewFileSet()
+	imports := make(map[string]*types.Package)
+
+	libFile, err := parser.ParseFile(fset, "lib.go", `package lib
+
+func Greet(name string) string {
+	return "hello, " + name
+}
+`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	libInfo := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	libPkg, err := new(types.Config).Check("lib", fset, []*ast.File{libFile}, libInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imports["lib"] = libPkg
+
+	mainFile, err := parser.ParseFile(fset, "main.go", `package main
+
+import "lib"
+
+func _() {
+	lib.Greet("a")
+}
+`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainInfo := &types.Info{Uses: make(map[*ast.Ident]types.Object)}
+	conf := types.Config{Importer: testImporter(imports)}
+	if _, err := conf.Check("main", fset, []*ast.File{mainFile}, mainInfo); err != nil {
+		t.Fatal(err)
+	}
+
+	greet := libPkg.Scope().Lookup("Greet")
+	ids := OccurrencesAcross([]*types.Info{libInfo, mainInfo}, greet)
+	if got, want := len(ids), 2; got != want { // 1 decl in lib + 1 use in main
+		t.Fatalf("got %d occurrences, want %d", got, want)
+	}
+	for _, id := range ids {
+		if id.Name != "Greet" {
+			t.Errorf("occurrence %v has name %q, want Greet", id, id.Name)
+		}
+	}
+}
+
+type testImporter map[string]*types.Package
+
+func (m testImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := m[path]; ok {
+		return pkg, nil
+	}
+	return nil, fmt.Errorf("refactor: package %q not found", path)
+}
+
+func TestRenameConflict(t *testing.T) {
+	pkg, info := check(t, `package p
+
+func greet(name string) string { return name }
+func wave() {}
+`)
+	greet := pkg.Scope().Lookup("greet")
+	if _, err := Rename(info, greet, "wave"); err == nil {
+		t.Fatal("Rename to an existing package-level name succeeded, want a Conflict error")
+	} else if _, ok := err.(*Conflict); !ok {
+		t.Errorf("Rename error has type %T, want *Conflict", err)
+	}
+
+	if ids, err := Rename(info, greet, "salute"); err != nil {
+		t.Fatalf("Rename to an unused name failed: %v", err)
+	} else if len(ids) != 1 {
+		t.Errorf("got %d occurrences, want 1", len(ids))
+	}
+}
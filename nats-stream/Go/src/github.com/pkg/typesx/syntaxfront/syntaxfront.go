@@ -0,0 +1,47 @@
+// Package syntaxfront lets a caller plug in an alternate parser ahead of
+// type-checking without Config.Check itself taking anything but
+// []*ast.File. Config.Check's files parameter is concretely []*ast.File
+// throughout the checker (typexpr.go, stmt.go, expr.go all consume *ast.*
+// nodes directly) - accepting a second syntax tree type like
+// cmd/compile/internal/syntax would mean putting every one of those
+// consumers behind an interface, which isn't a change reachable from
+// outside go/types. Frontend moves the seam to the call site instead: any
+// parser that can produce []*ast.File can be plugged in here, even if it
+// didn't start from Go source text at all.
+package syntaxfront
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// Frontend produces the []*ast.File a Check call needs.
+type Frontend interface {
+	Files(fset *token.FileSet) ([]*ast.File, error)
+}
+
+// Check adapts front to []*ast.File via Frontend.Files and type-checks the
+// result exactly like cfg.Check would.
+func Check(cfg types.Config, path string, fset *token.FileSet, front Frontend, info *types.Info) (*types.Package, error) {
+	files, err := front.Files(fset)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Check(path, fset, files, info)
+}
+
+// ASTFiles is the trivial Frontend wrapping an already-parsed []*ast.File -
+// what every existing caller of Config.Check already has.
+type ASTFiles []*ast.File
+
+func (f ASTFiles) Files(*token.FileSet) ([]*ast.File, error) { return f, nil }
+
+// FuncFrontend adapts a plain function to a Frontend, for a source that
+// isn't a pre-parsed file at all - e.g. one synthesizing an *ast.File from
+// a non-Go representation (a generated-code template, a schema) rather
+// than parsing Go source text.
+type FuncFrontend func(fset *token.FileSet) ([]*ast.File, error)
+
+// Files calls f.
+func (f FuncFrontend) Files(fset *token.FileSet) ([]*ast.File, error) { return f(fset) }
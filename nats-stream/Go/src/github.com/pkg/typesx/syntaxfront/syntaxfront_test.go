@@ -0,0 +1,68 @@
+package syntaxfront
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+// sourceFrontend parses src on demand, standing in for a frontend that
+// isn't just a pre-parsed []*ast.File - a real alternate parser would
+// implement Files the same way, against its own source instead.
+type sourceFrontend struct {
+	name, src string
+}
+
+func (f sourceFrontend) Files(fset *token.FileSet) ([]*ast.File, error) {
+	file, err := parser.ParseFile(fset, f.name, f.src, 0)
+	if err != nil {
+		return nil, err
+	}
+	return []*ast.File{file}, nil
+}
+
+func TestCheckWithSourceFrontend(t *testing.T) {
+	fset := token.NewFileSet()
+	front := sourceFrontend{name: "p.go", src: `package p; func F() int { return 1 }`}
+
+	pkg, err := Check(types.Config{}, "p", fset, front, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg.Scope().Lookup("F") == nil {
+		t.Error("checked package has no F")
+	}
+}
+
+func TestCheckWithFuncFrontend(t *testing.T) {
+	fset := token.NewFileSet()
+	front := FuncFrontend(func(fset *token.FileSet) ([]*ast.File, error) {
+		f, err := parser.ParseFile(fset, "synth.go", `package p; type T struct{ X int }`, 0)
+		if err != nil {
+			return nil, err
+		}
+		return []*ast.File{f}, nil
+	})
+
+	pkg, err := Check(types.Config{}, "p", fset, front, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg.Scope().Lookup("T") == nil {
+		t.Error("checked package has no T")
+	}
+}
+
+func TestCheckWithASTFiles(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", `package p`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Check(types.Config{}, "p", fset, ASTFiles{f}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,134 @@
+// Package workerpool runs a jsclient subscription's messages through a
+// bounded pool of worker goroutines, retrying a failing Handler with
+// exponential backoff before giving up on a message and republishing it to
+// a dead-letter subject instead of redelivering it forever.
+package workerpool
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/jsclient"
+)
+
+// Handler processes a single message and returns an error if it should be
+// retried (and eventually dead-lettered) rather than acked.
+type Handler func(msg *nats.Msg) error
+
+// Config bounds a Subscribe call's concurrency, redelivery, and
+// dead-lettering behaviour.
+type Config struct {
+	// Size is how many messages may be processed concurrently. Defaults to
+	// 1.
+	Size int
+	// MaxInFlight bounds how many unacked messages the server will deliver
+	// to this subscription at once, independent of Size. Defaults to Size.
+	MaxInFlight int
+	// AckWait bounds how long the server waits for a worker to ack a
+	// message before redelivering it. Defaults to 30s.
+	AckWait time.Duration
+	// MaxRedeliver is how many additional attempts a failing message gets
+	// before it is dead-lettered. Defaults to 3.
+	MaxRedeliver int
+	// RetryBackoff is the base delay before the first retry; attempt n
+	// waits RetryBackoff*2^(n-1). Defaults to 500ms.
+	RetryBackoff time.Duration
+	// DLQSubject is the subject (on the same stream) a message is
+	// republished to once it has exhausted MaxRedeliver, with headers
+	// recording why. If empty, exhausted messages are just terminated and
+	// dropped.
+	DLQSubject string
+}
+
+func (c Config) withDefaults() Config {
+	if c.Size <= 0 {
+		c.Size = 1
+	}
+	if c.MaxInFlight <= 0 {
+		c.MaxInFlight = c.Size
+	}
+	if c.AckWait == 0 {
+		c.AckWait = 30 * time.Second
+	}
+	if c.MaxRedeliver <= 0 {
+		c.MaxRedeliver = 3
+	}
+	if c.RetryBackoff == 0 {
+		c.RetryBackoff = 500 * time.Millisecond
+	}
+	return c
+}
+
+// Header keys workerpool sets on a message it republishes to DLQSubject.
+const (
+	HeaderFailureReason = "Workerpool-Failure-Reason"
+	HeaderAttempts      = "Workerpool-Attempts"
+)
+
+// Subscribe subscribes to stream.subject and runs every delivered message
+// through handler on a pool of cfg.Size goroutines. It always subscribes
+// with manual ack, since retry and dead-lettering both require controlling
+// when (and whether) a message is acked.
+func Subscribe(client *jsclient.Client, stream, subject string, handler Handler, opts jsclient.Options, cfg Config, subOpts ...jsclient.SubscribeOption) (*jsclient.Subscription, error) {
+	cfg = cfg.withDefaults()
+	sem := make(chan struct{}, cfg.Size)
+
+	dispatch := func(msg *nats.Msg) {
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			process(client, stream, msg, handler, cfg)
+		}()
+	}
+
+	subOpts = append(subOpts,
+		jsclient.WithManualAck(),
+		jsclient.WithMaxInFlight(cfg.MaxInFlight),
+		jsclient.WithAckWait(cfg.AckWait),
+	)
+	return client.Subscribe(stream, subject, dispatch, opts, subOpts...)
+}
+
+// process runs handler against msg, retrying with exponential backoff up to
+// cfg.MaxRedeliver times before dead-lettering and terminating it.
+func process(client *jsclient.Client, stream string, msg *nats.Msg, handler Handler, cfg Config) {
+	var err error
+	for attempt := 0; attempt <= cfg.MaxRedeliver; attempt++ {
+		if attempt > 0 {
+			time.Sleep(retryDelay(cfg, attempt))
+		}
+		if err = handler(msg); err == nil {
+			msg.Ack()
+			return
+		}
+		log.Printf("workerpool: attempt %d/%d failed for %s: %v", attempt+1, cfg.MaxRedeliver+1, msg.Subject, err)
+	}
+
+	if cfg.DLQSubject != "" {
+		dl := buildDeadLetter(stream, cfg.DLQSubject, msg, err, cfg.MaxRedeliver+1)
+		if dlErr := client.PublishMsg(stream, dl, jsclient.Options{}); dlErr != nil {
+			log.Printf("workerpool: dead-letter publish failed for %s: %v", msg.Subject, dlErr)
+		}
+	}
+	msg.Term()
+}
+
+// retryDelay is how long process waits before attempt, counting from 1: the
+// first retry waits cfg.RetryBackoff, the next 2x that, the next 4x, and so
+// on.
+func retryDelay(cfg Config, attempt int) time.Duration {
+	return cfg.RetryBackoff * time.Duration(uint64(1)<<uint(attempt-1))
+}
+
+// buildDeadLetter builds the message process republishes to subject once
+// msg has exhausted its retries, recording why and how many attempts it
+// took in headers rather than silently dropping that context.
+func buildDeadLetter(stream, subject string, msg *nats.Msg, reason error, attempts int) *nats.Msg {
+	dl := nats.NewMsg(jsclient.Channel(stream, subject))
+	dl.Data = msg.Data
+	dl.Header.Set(HeaderFailureReason, reason.Error())
+	dl.Header.Set(HeaderAttempts, strconv.Itoa(attempts))
+	return dl
+}
@@ -0,0 +1,63 @@
+package workerpool
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestConfigWithDefaults(t *testing.T) {
+	cfg := Config{}.withDefaults()
+	if cfg.Size != 1 {
+		t.Errorf("Size = %d, want 1", cfg.Size)
+	}
+	if cfg.MaxInFlight != cfg.Size {
+		t.Errorf("MaxInFlight = %d, want Size (%d)", cfg.MaxInFlight, cfg.Size)
+	}
+	if cfg.AckWait != 30*time.Second {
+		t.Errorf("AckWait = %v, want 30s", cfg.AckWait)
+	}
+	if cfg.MaxRedeliver != 3 {
+		t.Errorf("MaxRedeliver = %d, want 3", cfg.MaxRedeliver)
+	}
+	if cfg.RetryBackoff != 500*time.Millisecond {
+		t.Errorf("RetryBackoff = %v, want 500ms", cfg.RetryBackoff)
+	}
+
+	explicit := Config{Size: 4}.withDefaults()
+	if explicit.MaxInFlight != 4 {
+		t.Errorf("MaxInFlight = %d, want Size (4) when MaxInFlight is left unset", explicit.MaxInFlight)
+	}
+}
+
+func TestRetryDelayDoublesEachAttempt(t *testing.T) {
+	cfg := Config{RetryBackoff: 100 * time.Millisecond}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}
+	for i, w := range want {
+		if got := retryDelay(cfg, i+1); got != w {
+			t.Errorf("retryDelay(attempt=%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func TestBuildDeadLetterSetsHeaders(t *testing.T) {
+	msg := nats.NewMsg("books.book.created")
+	msg.Data = []byte("payload")
+
+	dl := buildDeadLetter("books", "catalog-dead-letter", msg, errors.New("handler exploded"), 4)
+
+	if dl.Subject != "books.catalog-dead-letter" {
+		t.Errorf("Subject = %q, want books.catalog-dead-letter", dl.Subject)
+	}
+	if string(dl.Data) != "payload" {
+		t.Errorf("Data = %q, want payload", dl.Data)
+	}
+	if got, want := dl.Header.Get(HeaderFailureReason), "handler exploded"; got != want {
+		t.Errorf("%s header = %q, want %q", HeaderFailureReason, got, want)
+	}
+	if got, want := dl.Header.Get(HeaderAttempts), "4"; got != want {
+		t.Errorf("%s header = %q, want %q", HeaderAttempts, got, want)
+	}
+}
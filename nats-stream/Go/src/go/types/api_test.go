@@ -2460,4 +2460,6 @@ func (V4) M()
 
 	// V4 has no method m but has M. Should not report wrongType.
 	checkMissingMethod("V4", false)
-}
\ No newline at end of file
+}
+
+